@@ -6,7 +6,7 @@ export default function LeaderboardSimple({ onBack, nkClient, session }) {
 	const [rows, setRows] = useState([]);
 	const [loading, setLoading] = useState(false);
 
-	const leaderboardId = "tictactoe_global";
+	const leaderboardId = "tictactoe_ranked_normal";
 
 	useEffect(() => {
 		const fetchLeaderboard = async () => {
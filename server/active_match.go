@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// The match each player has a round in progress in, if any, so they can find their way back to it.
+	activeMatchCollection = "active_matches"
+	activeMatchKey        = "current"
+
+	notificationCodeActiveMatch = 105
+)
+
+type activeMatch struct {
+	MatchID string `json:"match_id"`
+}
+
+// Note the match as the players' active one as a round starts. Failing to is logged, but doesn't hold up the match.
+// AI matches aren't noted, the AI leaves along with the player so there's no round to go back to.
+func setActiveMatch(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, s *MatchState) {
+	if s.ai {
+		return
+	}
+	matchID, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
+	value, err := json.Marshal(&activeMatch{MatchID: matchID})
+	if err != nil {
+		logger.Error("error encoding active match: %v", err)
+		return
+	}
+
+	writes := make([]*runtime.StorageWrite, 0, len(s.marks))
+	for userID := range s.marks {
+		if userID == aiUserId {
+			continue
+		}
+		writes = append(writes, &runtime.StorageWrite{
+			Collection:      activeMatchCollection,
+			Key:             activeMatchKey,
+			UserID:          userID,
+			Value:           string(value),
+			PermissionRead:  1, // only the owner can read
+			PermissionWrite: 0, // only the server can write
+		})
+	}
+	if len(writes) == 0 {
+		return
+	}
+	if _, err := nk.StorageWrite(ctx, writes); err != nil {
+		logger.Error("error writing active match %s: %v", matchID, err)
+	}
+}
+
+// Clear the players' active match once the round is over. A player left with a stale one is put right the next time
+// it's looked up.
+func clearActiveMatch(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, s *MatchState) {
+	deletes := make([]*runtime.StorageDelete, 0, len(s.marks))
+	for userID := range s.marks {
+		if userID == aiUserId {
+			continue
+		}
+		deletes = append(deletes, &runtime.StorageDelete{
+			Collection: activeMatchCollection,
+			Key:        activeMatchKey,
+			UserID:     userID,
+		})
+	}
+	if len(deletes) == 0 {
+		return
+	}
+	if err := nk.StorageDelete(ctx, deletes); err != nil {
+		logger.Error("error clearing active match: %v", err)
+	}
+}
+
+// Look up the match a player has a round in progress in. Empty if there's none, or the match has since closed.
+func readActiveMatch(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, userID string) (string, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: activeMatchCollection,
+		Key:        activeMatchKey,
+		UserID:     userID,
+	}})
+	if err != nil {
+		return "", err
+	}
+	if len(objects) == 0 {
+		return "", nil
+	}
+	active := &activeMatch{}
+	if err := json.Unmarshal([]byte(objects[0].Value), active); err != nil {
+		return "", err
+	}
+
+	// Matches that close mid-round leave the record behind, clear it up.
+	if match, err := nk.MatchGet(ctx, active.MatchID); err != nil || match == nil {
+		if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+			Collection: activeMatchCollection,
+			Key:        activeMatchKey,
+			UserID:     userID,
+			Version:    objects[0].Version,
+		}}); err != nil {
+			logger.Warn("error clearing active match of %s: %v", userID, err)
+		}
+		return "", nil
+	}
+	return active.MatchID, nil
+}
+
+// Get the match the caller has a round in progress in, if any, to rejoin it after the app has restarted.
+func rpcGetActiveMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		matchID, err := readActiveMatch(ctx, nk, logger, userID)
+		if err != nil {
+			logger.Error("error reading active match of %s: %v", userID, err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(&api.RpcGetActiveMatchResponse{MatchId: matchID})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
@@ -15,20 +15,41 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
+	"math/rand"
 	"time"
 
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/bot"
+	"github.com/heroiclabs/nakama-project-template/game"
 )
 
 const aiUserId = "ai-user-id"
 
+const (
+	// The AI has until this long before the turn clock runs out to work out its move...
+	aiThinkMarginSec = 2
+	// ...and if it still hasn't this close to the end, any legal move is played rather than forfeit the round.
+	aiFallbackSec = 1
+)
+
+type aiLevel struct {
+	// How loosely moves are sampled. Zero always takes the best move.
+	temperature float64
+	// How often the AI ignores the wins and blocks on the board.
+	blunderChance float64
+}
+
+var aiLevels = map[api.Difficulty]aiLevel{
+	api.Difficulty_DIFFICULTY_EASY:   {temperature: 3, blunderChance: 0.6},
+	api.Difficulty_DIFFICULTY_MEDIUM: {temperature: 1, blunderChance: 0.25},
+	api.Difficulty_DIFFICULTY_HARD:   {temperature: 0, blunderChance: 0},
+}
+
 var aiPresenceObj = &aiPresence{}
 
 var _ runtime.Presence = (*aiPresence)(nil)
@@ -38,9 +59,21 @@ type aiPresence struct{}
 type aiMatchData struct {
 	opCode api.OpCode
 	data   []byte
+	// The AI request the move answers.
+	requestID int64
 	*aiPresence
 }
 
+// Everything the AI needs to work out a move away from the match loop. The game is a copy, so the match can carry on
+// while the AI thinks.
+type aiRequest struct {
+	id         int64
+	game       *game.Game
+	provider   bot.MoveProvider
+	difficulty api.Difficulty
+	random     *rand.Rand
+}
+
 func (ap *aiPresence) GetHidden() bool {
 	return false
 }
@@ -89,92 +122,164 @@ func (amd *aiMatchData) GetReceiveTime() int64 {
 	return time.Now().UTC().Unix()
 }
 
-type cell [2]int
-type row [3]cell
-type board [3]row
-
-type tfRequest struct {
-	Instances [1]board `json:"instances"`
-}
-
-type tfResponse struct {
-	Predictions [][]float64 `json:"predictions"`
-}
+// Start working out the AI's move in the background, so a slow model never holds up the match loop. It has until
+// shortly before the turn clock runs out, and the move comes back through s.messages tagged with the request it
+// answers, so one for a board that has since changed can be told apart.
+func (m *MatchHandler) startAiTurn(ctx context.Context, logger runtime.Logger, s *MatchState) {
+	s.aiRequestID++
+	req := &aiRequest{
+		id:         s.aiRequestID,
+		game:       s.game.Clone(),
+		provider:   s.aiProvider,
+		difficulty: s.difficulty,
+		random:     rand.New(rand.NewSource(s.random.Int63())),
+	}
 
-func (m *MatchHandler) aiTurn(s *MatchState) error {
-	// Convert board state into expected model format
-	b := board{}
+	thinkTicks := max(s.deadlineRemainingTicks-aiThinkMarginSec*tickRate, 0)
+	aiCtx, cancel := context.WithTimeout(ctx, time.Duration(thinkTicks)*time.Second/tickRate)
+	s.aiPending = true
+	s.aiCancel = cancel
 
-	for i, mark := range s.board {
-		rowIdx := i / 3
-		cellIdx := i % 3
+	messages := s.messages
+	go func() {
+		defer cancel()
 
-		switch mark {
-		case s.marks[aiUserId]: // AI
-			b[rowIdx][cellIdx] = cell{1, 0}
-		case api.Mark_MARK_UNSPECIFIED:
-			b[rowIdx][cellIdx] = cell{0, 0}
-		default: // Player
-			b[rowIdx][cellIdx] = cell{0, 1}
+		move, err := m.aiMove(aiCtx, logger, req)
+		if err != nil {
+			logger.Error("error making AI turn: %v", err)
+			return
+		}
+		if errors.Is(aiCtx.Err(), context.Canceled) {
+			// The match has moved on without it.
+			return
+		}
+		if err := m.queueAiMove(messages, req.id, move); err != nil {
+			logger.Error("error making AI turn: %v", err)
 		}
+	}()
+}
+
+// Abandon the AI's move in progress, if any. Should it still arrive, it is discarded.
+func cancelAiTurn(s *MatchState) {
+	if s.aiCancel != nil {
+		s.aiCancel()
+		s.aiCancel = nil
 	}
+	s.aiPending = false
+}
 
-	// Send the vectors to TF
-	req := tfRequest{Instances: [1]board{b}}
-	raw, err := json.Marshal(req)
+// Append the AI's move to s.messages, to be consumed by the next loop run. If a move for the same request is already
+// waiting there, it's played instead.
+func (m *MatchHandler) queueAiMove(messages chan runtime.MatchData, requestID int64, position int32) error {
+	rawMove, err := m.marshaler.Marshal(&api.Move{Position: position})
 	if err != nil {
-		return fmt.Errorf("failed to marshal TF request: %w", err)
+		return fmt.Errorf("failed to marshal AI move: %w", err)
 	}
 
-	resp, err := http.Post(
-		m.tfServingAddress, "application/json", bytes.NewReader(raw))
-
-	if err != nil {
-		return fmt.Errorf("failed to make TF request: %w", err)
+	data := &aiMatchData{
+		opCode:     api.OpCode_OPCODE_MOVE,
+		data:       rawMove,
+		requestID:  requestID,
+		aiPresence: aiPresenceObj,
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to make TF request: %w", err)
+	select {
+	case messages <- data:
+	default:
 	}
+	return nil
+}
 
-	// Convert response into message
-	predictions := tfResponse{}
-	if err := json.Unmarshal(respBody, &predictions); err != nil {
-		return fmt.Errorf("failed to unmarshal TF response: %w", err)
+// Pick the AI's move at the match's difficulty. Every legal move is scored, by the match's move provider or by the
+// solver if the provider can't answer, then weaker levels may ignore the wins and blocks on the board and sample a
+// move rather than take the best one. Providers that only pick a single move, like the scripted bot, are played as is.
+func (m *MatchHandler) aiMove(ctx context.Context, logger runtime.Logger, req *aiRequest) (int32, error) {
+	mark := req.game.Mark()
+	if req.difficulty == api.Difficulty_DIFFICULTY_PERFECT {
+		move, err := m.solver.Move(ctx, req.game, mark)
+		if err != nil {
+			return -1, fmt.Errorf("failed to solve AI move: %w", err)
+		}
+		return move, nil
 	}
 
-	if len(predictions.Predictions) != 1 {
-		return fmt.Errorf("received unexpected TF response: %w", err)
+	moves := req.game.LegalMoves()
+	if len(moves) == 0 {
+		return -1, fmt.Errorf("no legal AI move")
 	}
 
-	// Find the index with the highest predicted value
-	maxVal := math.Inf(-1)
-	aiMovePos := -1
-	for i, val := range predictions.Predictions[0] {
-		if val > maxVal {
-			maxVal = val
-			aiMovePos = i
+	// Higher is better, on a log scale so they can be sampled from with a temperature.
+	var logits map[int32]float64
+	if scorer, ok := req.provider.(bot.Scorer); ok {
+		scores, err := scorer.Scores(ctx, req.game, mark)
+		if err != nil {
+			logger.Warn("AI move scores unavailable, falling back to solver: %v", err)
+		} else {
+			logits = scores
+		}
+	} else {
+		move, err := req.provider.Move(ctx, req.game, mark)
+		if err == nil {
+			return move, nil
 		}
+		logger.Warn("AI move unavailable, falling back to solver: %v", err)
 	}
-
-	// Append message to m.messages to be consumed by the next loop run
-	if aiMovePos > -1 {
-		move := &api.Move{Position: int32(aiMovePos)}
-		rawMove, err := m.marshaler.Marshal(move)
+	if logits == nil {
+		scores, err := m.solver.Scores(ctx, req.game, mark)
 		if err != nil {
-			return fmt.Errorf("failed to marshal AI move: %w", err)
+			return -1, fmt.Errorf("failed to solve AI move: %w", err)
+		}
+		logits = scores
+	}
+
+	level := aiLevels[req.difficulty]
+	if req.random.Float64() < level.blunderChance {
+		// Look straight past any line either player could complete, as long as there's something else to play.
+		skip := make(map[int32]bool)
+		for _, pos := range req.game.WinningMoves(req.game.Mark()) {
+			skip[pos] = true
+		}
+		for _, pos := range req.game.WinningMoves(game.Opponent(req.game.Mark())) {
+			skip[pos] = true
 		}
 
-		data := &aiMatchData{
-			opCode:     api.OpCode_OPCODE_MOVE,
-			data:       rawMove,
-			aiPresence: aiPresenceObj,
+		candidates := make([]int32, 0, len(moves))
+		for _, pos := range moves {
+			if !skip[pos] {
+				candidates = append(candidates, pos)
+			}
+		}
+		if len(candidates) > 0 {
+			moves = candidates
 		}
+	}
 
-		s.messages <- data
+	if level.temperature == 0 {
+		bestMove := moves[0]
+		for _, pos := range moves {
+			if logits[pos] > logits[bestMove] {
+				bestMove = pos
+			}
+		}
+		return bestMove, nil
 	}
 
-	return nil
+	// Sample from the softmax of the logits. The higher the temperature, the closer to a uniformly random move.
+	maxLogit := math.Inf(-1)
+	for _, pos := range moves {
+		maxLogit = math.Max(maxLogit, logits[pos])
+	}
+	weights := make([]float64, len(moves))
+	total := 0.0
+	for i, pos := range moves {
+		weights[i] = math.Exp((logits[pos] - maxLogit) / level.temperature)
+		total += weights[i]
+	}
+	r := req.random.Float64() * total
+	for i, pos := range moves {
+		if r -= weights[i]; r <= 0 {
+			return pos, nil
+		}
+	}
+	return moves[len(moves)-1], nil
 }
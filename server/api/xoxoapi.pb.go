@@ -89,6 +89,181 @@ func (Mark) EnumDescriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{0}
 }
 
+// How well the AI plays.
+type Difficulty int32
+
+const (
+	// No difficulty specified, the AI plays hard.
+	Difficulty_DIFFICULTY_UNSPECIFIED Difficulty = 0
+	// Picks moves loosely, and often misses wins and blocks.
+	Difficulty_DIFFICULTY_EASY Difficulty = 1
+	// Picks moves in proportion to how good they look, and sometimes misses wins and blocks.
+	Difficulty_DIFFICULTY_MEDIUM Difficulty = 2
+	// Always plays the move that looks best.
+	Difficulty_DIFFICULTY_HARD Difficulty = 3
+	// Never loses.
+	Difficulty_DIFFICULTY_PERFECT Difficulty = 4
+)
+
+// Enum value maps for Difficulty.
+var (
+	Difficulty_name = map[int32]string{
+		0: "DIFFICULTY_UNSPECIFIED",
+		1: "DIFFICULTY_EASY",
+		2: "DIFFICULTY_MEDIUM",
+		3: "DIFFICULTY_HARD",
+		4: "DIFFICULTY_PERFECT",
+	}
+	Difficulty_value = map[string]int32{
+		"DIFFICULTY_UNSPECIFIED": 0,
+		"DIFFICULTY_EASY":        1,
+		"DIFFICULTY_MEDIUM":      2,
+		"DIFFICULTY_HARD":        3,
+		"DIFFICULTY_PERFECT":     4,
+	}
+)
+
+func (x Difficulty) Enum() *Difficulty {
+	p := new(Difficulty)
+	*p = x
+	return p
+}
+
+func (x Difficulty) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Difficulty) Descriptor() protoreflect.EnumDescriptor {
+	return file_xoxoapi_proto_enumTypes[1].Descriptor()
+}
+
+func (Difficulty) Type() protoreflect.EnumType {
+	return &file_xoxoapi_proto_enumTypes[1]
+}
+
+func (x Difficulty) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Difficulty.Descriptor instead.
+func (Difficulty) EnumDescriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{1}
+}
+
+// How a game came to an end.
+type GameEndReason int32
+
+const (
+	// No reason specified. Unused.
+	GameEndReason_GAME_END_REASON_UNSPECIFIED GameEndReason = 0
+	// A player completed a winning line.
+	GameEndReason_GAME_END_REASON_LINE GameEndReason = 1
+	// The board filled up without a winner.
+	GameEndReason_GAME_END_REASON_TIE GameEndReason = 2
+	// A player ran out of time.
+	GameEndReason_GAME_END_REASON_FORFEIT GameEndReason = 3
+	// A player left the game.
+	GameEndReason_GAME_END_REASON_DISCONNECT GameEndReason = 4
+)
+
+// Enum value maps for GameEndReason.
+var (
+	GameEndReason_name = map[int32]string{
+		0: "GAME_END_REASON_UNSPECIFIED",
+		1: "GAME_END_REASON_LINE",
+		2: "GAME_END_REASON_TIE",
+		3: "GAME_END_REASON_FORFEIT",
+		4: "GAME_END_REASON_DISCONNECT",
+	}
+	GameEndReason_value = map[string]int32{
+		"GAME_END_REASON_UNSPECIFIED": 0,
+		"GAME_END_REASON_LINE":        1,
+		"GAME_END_REASON_TIE":         2,
+		"GAME_END_REASON_FORFEIT":     3,
+		"GAME_END_REASON_DISCONNECT":  4,
+	}
+)
+
+func (x GameEndReason) Enum() *GameEndReason {
+	p := new(GameEndReason)
+	*p = x
+	return p
+}
+
+func (x GameEndReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameEndReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_xoxoapi_proto_enumTypes[2].Descriptor()
+}
+
+func (GameEndReason) Type() protoreflect.EnumType {
+	return &file_xoxoapi_proto_enumTypes[2]
+}
+
+func (x GameEndReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameEndReason.Descriptor instead.
+func (GameEndReason) EnumDescriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{2}
+}
+
+// File formats training data can be exported in.
+type TrainingDataFormat int32
+
+const (
+	// No format specified, JSONL is used.
+	TrainingDataFormat_TRAINING_DATA_FORMAT_UNSPECIFIED TrainingDataFormat = 0
+	// One JSON example per line.
+	TrainingDataFormat_TRAINING_DATA_FORMAT_JSONL TrainingDataFormat = 1
+	// tf.train.Example records in a TFRecord file.
+	TrainingDataFormat_TRAINING_DATA_FORMAT_TFRECORD TrainingDataFormat = 2
+)
+
+// Enum value maps for TrainingDataFormat.
+var (
+	TrainingDataFormat_name = map[int32]string{
+		0: "TRAINING_DATA_FORMAT_UNSPECIFIED",
+		1: "TRAINING_DATA_FORMAT_JSONL",
+		2: "TRAINING_DATA_FORMAT_TFRECORD",
+	}
+	TrainingDataFormat_value = map[string]int32{
+		"TRAINING_DATA_FORMAT_UNSPECIFIED": 0,
+		"TRAINING_DATA_FORMAT_JSONL":       1,
+		"TRAINING_DATA_FORMAT_TFRECORD":    2,
+	}
+)
+
+func (x TrainingDataFormat) Enum() *TrainingDataFormat {
+	p := new(TrainingDataFormat)
+	*p = x
+	return p
+}
+
+func (x TrainingDataFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrainingDataFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_xoxoapi_proto_enumTypes[3].Descriptor()
+}
+
+func (TrainingDataFormat) Type() protoreflect.EnumType {
+	return &file_xoxoapi_proto_enumTypes[3]
+}
+
+func (x TrainingDataFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrainingDataFormat.Descriptor instead.
+func (TrainingDataFormat) EnumDescriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{3}
+}
+
 // The complete set of opcodes used for communication between clients and server.
 type OpCode int32
 
@@ -109,29 +284,52 @@ const (
 	OpCode_OPCODE_OPPONENT_LEFT OpCode = 6
 	// Invite AI player to join instead of the opponent who left the game.
 	OpCode_OPCODE_INVITE_AI OpCode = 7
+	// Sent by a player to ask for another round once a round has completed. Relayed by the server to the
+	// players with the current state of the vote.
+	OpCode_OPCODE_REMATCH_REQUEST OpCode = 8
+	// Sent by a player to agree to a rematch the opponent asked for.
+	OpCode_OPCODE_REMATCH_ACCEPT OpCode = 9
+	// Sent by a player to refuse a rematch. Broadcast by the server when the rematch is declined or the vote
+	// times out, after which the players are removed from the match.
+	OpCode_OPCODE_REMATCH_DECLINE OpCode = 10
+	// The opponent lost their connection mid-round. The round waits for them to rejoin until the deadline the
+	// message carries, with their turn clock paused, after which they forfeit.
+	OpCode_OPCODE_OPPONENT_DISCONNECTED OpCode = 11
+	// The opponent rejoined in time and the round carries on.
+	OpCode_OPCODE_OPPONENT_RECONNECTED OpCode = 12
 )
 
 // Enum value maps for OpCode.
 var (
 	OpCode_name = map[int32]string{
-		0: "OPCODE_UNSPECIFIED",
-		1: "OPCODE_START",
-		2: "OPCODE_UPDATE",
-		3: "OPCODE_DONE",
-		4: "OPCODE_MOVE",
-		5: "OPCODE_REJECTED",
-		6: "OPCODE_OPPONENT_LEFT",
-		7: "OPCODE_INVITE_AI",
+		0:  "OPCODE_UNSPECIFIED",
+		1:  "OPCODE_START",
+		2:  "OPCODE_UPDATE",
+		3:  "OPCODE_DONE",
+		4:  "OPCODE_MOVE",
+		5:  "OPCODE_REJECTED",
+		6:  "OPCODE_OPPONENT_LEFT",
+		7:  "OPCODE_INVITE_AI",
+		8:  "OPCODE_REMATCH_REQUEST",
+		9:  "OPCODE_REMATCH_ACCEPT",
+		10: "OPCODE_REMATCH_DECLINE",
+		11: "OPCODE_OPPONENT_DISCONNECTED",
+		12: "OPCODE_OPPONENT_RECONNECTED",
 	}
 	OpCode_value = map[string]int32{
-		"OPCODE_UNSPECIFIED":   0,
-		"OPCODE_START":         1,
-		"OPCODE_UPDATE":        2,
-		"OPCODE_DONE":          3,
-		"OPCODE_MOVE":          4,
-		"OPCODE_REJECTED":      5,
-		"OPCODE_OPPONENT_LEFT": 6,
-		"OPCODE_INVITE_AI":     7,
+		"OPCODE_UNSPECIFIED":           0,
+		"OPCODE_START":                 1,
+		"OPCODE_UPDATE":                2,
+		"OPCODE_DONE":                  3,
+		"OPCODE_MOVE":                  4,
+		"OPCODE_REJECTED":              5,
+		"OPCODE_OPPONENT_LEFT":         6,
+		"OPCODE_INVITE_AI":             7,
+		"OPCODE_REMATCH_REQUEST":       8,
+		"OPCODE_REMATCH_ACCEPT":        9,
+		"OPCODE_REMATCH_DECLINE":       10,
+		"OPCODE_OPPONENT_DISCONNECTED": 11,
+		"OPCODE_OPPONENT_RECONNECTED":  12,
 	}
 )
 
@@ -146,11 +344,11 @@ func (x OpCode) String() string {
 }
 
 func (OpCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_xoxoapi_proto_enumTypes[1].Descriptor()
+	return file_xoxoapi_proto_enumTypes[4].Descriptor()
 }
 
 func (OpCode) Type() protoreflect.EnumType {
-	return &file_xoxoapi_proto_enumTypes[1]
+	return &file_xoxoapi_proto_enumTypes[4]
 }
 
 func (x OpCode) Number() protoreflect.EnumNumber {
@@ -159,10 +357,72 @@ func (x OpCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OpCode.Descriptor instead.
 func (OpCode) EnumDescriptor() ([]byte, []int) {
-	return file_xoxoapi_proto_rawDescGZIP(), []int{1}
+	return file_xoxoapi_proto_rawDescGZIP(), []int{4}
+}
+
+// Where a challenge to a friend stands.
+type ChallengeStatus int32
+
+const (
+	// No status specified. Unused.
+	ChallengeStatus_CHALLENGE_STATUS_UNSPECIFIED ChallengeStatus = 0
+	// Waiting for the friend to answer.
+	ChallengeStatus_CHALLENGE_STATUS_PENDING ChallengeStatus = 1
+	// The friend accepted, and joins the match.
+	ChallengeStatus_CHALLENGE_STATUS_ACCEPTED ChallengeStatus = 2
+	// The friend declined, and the match has closed.
+	ChallengeStatus_CHALLENGE_STATUS_DECLINED ChallengeStatus = 3
+	// The friend didn't join in time, and the match has closed.
+	ChallengeStatus_CHALLENGE_STATUS_EXPIRED ChallengeStatus = 4
+)
+
+// Enum value maps for ChallengeStatus.
+var (
+	ChallengeStatus_name = map[int32]string{
+		0: "CHALLENGE_STATUS_UNSPECIFIED",
+		1: "CHALLENGE_STATUS_PENDING",
+		2: "CHALLENGE_STATUS_ACCEPTED",
+		3: "CHALLENGE_STATUS_DECLINED",
+		4: "CHALLENGE_STATUS_EXPIRED",
+	}
+	ChallengeStatus_value = map[string]int32{
+		"CHALLENGE_STATUS_UNSPECIFIED": 0,
+		"CHALLENGE_STATUS_PENDING":     1,
+		"CHALLENGE_STATUS_ACCEPTED":    2,
+		"CHALLENGE_STATUS_DECLINED":    3,
+		"CHALLENGE_STATUS_EXPIRED":     4,
+	}
+)
+
+func (x ChallengeStatus) Enum() *ChallengeStatus {
+	p := new(ChallengeStatus)
+	*p = x
+	return p
+}
+
+func (x ChallengeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChallengeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_xoxoapi_proto_enumTypes[5].Descriptor()
+}
+
+func (ChallengeStatus) Type() protoreflect.EnumType {
+	return &file_xoxoapi_proto_enumTypes[5]
+}
+
+func (x ChallengeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChallengeStatus.Descriptor instead.
+func (ChallengeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{5}
 }
 
 // Message data sent by server to clients representing a new game round starting.
+// Spectators joining a round in progress also receive it, with the board as it currently stands.
 type Start struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -176,6 +436,18 @@ type Start struct {
 	Mark Mark `protobuf:"varint,3,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
 	// The deadline time by which the player must submit their move, or forfeit.
 	Deadline int64 `protobuf:"varint,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Number of columns on the board.
+	Width int32 `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	// Number of rows on the board. The board is sent row by row, so position = row * width + column.
+	Height int32 `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	// How many marks in a row are needed to win.
+	WinLength int32 `protobuf:"varint,7,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// The round of the series being played, starting at 1.
+	RoundNumber int32 `protobuf:"varint,8,opt,name=round_number,json=roundNumber,proto3" json:"round_number,omitempty"`
+	// Number of rounds in the series. The first player to win a majority of them wins the series.
+	SeriesLength int32 `protobuf:"varint,9,opt,name=series_length,json=seriesLength,proto3" json:"series_length,omitempty"`
+	// Rounds won so far in the series, by user ID.
+	SeriesScore map[string]int32 `protobuf:"bytes,10,rep,name=series_score,json=seriesScore,proto3" json:"series_score,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *Start) Reset() {
@@ -238,6 +510,48 @@ func (x *Start) GetDeadline() int64 {
 	return 0
 }
 
+func (x *Start) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Start) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Start) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *Start) GetRoundNumber() int32 {
+	if x != nil {
+		return x.RoundNumber
+	}
+	return 0
+}
+
+func (x *Start) GetSeriesLength() int32 {
+	if x != nil {
+		return x.SeriesLength
+	}
+	return 0
+}
+
+func (x *Start) GetSeriesScore() map[string]int32 {
+	if x != nil {
+		return x.SeriesScore
+	}
+	return nil
+}
+
 // A game state update sent by the server to clients.
 type Update struct {
 	state         protoimpl.MessageState
@@ -318,8 +632,17 @@ type Done struct {
 	// Winner board positions, if any. Used to display the row, column, or diagonal that won the game.
 	// May be empty if it's a draw or the winner is by forfeit.
 	WinnerPositions []int32 `protobuf:"varint,3,rep,packed,name=winner_positions,json=winnerPositions,proto3" json:"winner_positions,omitempty"`
-	// Next round start time.
+	// Next round start time, if the series continues.
 	NextGameStart int64 `protobuf:"varint,4,opt,name=next_game_start,json=nextGameStart,proto3" json:"next_game_start,omitempty"`
+	// The deadline time by which both players must agree to a rematch, or the match ends.
+	RematchDeadline int64 `protobuf:"varint,5,opt,name=rematch_deadline,json=rematchDeadline,proto3" json:"rematch_deadline,omitempty"`
+	// The round of the series that has just completed, starting at 1.
+	RoundNumber int32 `protobuf:"varint,6,opt,name=round_number,json=roundNumber,proto3" json:"round_number,omitempty"`
+	// Rounds won so far in the series, by user ID, including this one.
+	SeriesScore map[string]int32 `protobuf:"bytes,7,rep,name=series_score,json=seriesScore,proto3" json:"series_score,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// True if this round decided the series. The players then vote on a rematch, otherwise the next round
+	// starts automatically at next_game_start.
+	SeriesOver bool `protobuf:"varint,8,opt,name=series_over,json=seriesOver,proto3" json:"series_over,omitempty"`
 }
 
 func (x *Done) Reset() {
@@ -382,18 +705,48 @@ func (x *Done) GetNextGameStart() int64 {
 	return 0
 }
 
-// A player intends to make a move.
-type Move struct {
+func (x *Done) GetRematchDeadline() int64 {
+	if x != nil {
+		return x.RematchDeadline
+	}
+	return 0
+}
+
+func (x *Done) GetRoundNumber() int32 {
+	if x != nil {
+		return x.RoundNumber
+	}
+	return 0
+}
+
+func (x *Done) GetSeriesScore() map[string]int32 {
+	if x != nil {
+		return x.SeriesScore
+	}
+	return nil
+}
+
+func (x *Done) GetSeriesOver() bool {
+	if x != nil {
+		return x.SeriesOver
+	}
+	return false
+}
+
+// The state of a rematch vote, sent by the server to the players after a round has completed.
+type Rematch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The position the player wants to place their mark in.
-	Position int32 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	// User IDs of the players who have agreed to play another round.
+	Accepted []string `protobuf:"bytes,1,rep,name=accepted,proto3" json:"accepted,omitempty"`
+	// The deadline time by which both players must agree to a rematch, or the match ends.
+	Deadline int64 `protobuf:"varint,2,opt,name=deadline,proto3" json:"deadline,omitempty"`
 }
 
-func (x *Move) Reset() {
-	*x = Move{}
+func (x *Rematch) Reset() {
+	*x = Rematch{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_xoxoapi_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -401,13 +754,13 @@ func (x *Move) Reset() {
 	}
 }
 
-func (x *Move) String() string {
+func (x *Rematch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Move) ProtoMessage() {}
+func (*Rematch) ProtoMessage() {}
 
-func (x *Move) ProtoReflect() protoreflect.Message {
+func (x *Rematch) ProtoReflect() protoreflect.Message {
 	mi := &file_xoxoapi_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,32 +772,39 @@ func (x *Move) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Move.ProtoReflect.Descriptor instead.
-func (*Move) Descriptor() ([]byte, []int) {
+// Deprecated: Use Rematch.ProtoReflect.Descriptor instead.
+func (*Rematch) Descriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Move) GetPosition() int32 {
+func (x *Rematch) GetAccepted() []string {
 	if x != nil {
-		return x.Position
+		return x.Accepted
+	}
+	return nil
+}
+
+func (x *Rematch) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
 	}
 	return 0
 }
 
-// Payload for an RPC request to find a match.
-type RpcFindMatchRequest struct {
+// Sent by the server to a player whose opponent has lost their connection mid-round.
+type OpponentDisconnected struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// User can choose a fast or normal speed match.
-	Fast bool `protobuf:"varint,1,opt,name=fast,proto3" json:"fast,omitempty"`
-	// User can choose whether to play with AI
-	Ai bool `protobuf:"varint,2,opt,name=ai,proto3" json:"ai,omitempty"`
+	// The opponent's user ID.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The deadline time by which the opponent must rejoin, or forfeit.
+	Deadline int64 `protobuf:"varint,2,opt,name=deadline,proto3" json:"deadline,omitempty"`
 }
 
-func (x *RpcFindMatchRequest) Reset() {
-	*x = RpcFindMatchRequest{}
+func (x *OpponentDisconnected) Reset() {
+	*x = OpponentDisconnected{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_xoxoapi_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -452,13 +812,13 @@ func (x *RpcFindMatchRequest) Reset() {
 	}
 }
 
-func (x *RpcFindMatchRequest) String() string {
+func (x *OpponentDisconnected) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RpcFindMatchRequest) ProtoMessage() {}
+func (*OpponentDisconnected) ProtoMessage() {}
 
-func (x *RpcFindMatchRequest) ProtoReflect() protoreflect.Message {
+func (x *OpponentDisconnected) ProtoReflect() protoreflect.Message {
 	mi := &file_xoxoapi_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -470,37 +830,37 @@ func (x *RpcFindMatchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RpcFindMatchRequest.ProtoReflect.Descriptor instead.
-func (*RpcFindMatchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OpponentDisconnected.ProtoReflect.Descriptor instead.
+func (*OpponentDisconnected) Descriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RpcFindMatchRequest) GetFast() bool {
+func (x *OpponentDisconnected) GetUserId() string {
 	if x != nil {
-		return x.Fast
+		return x.UserId
 	}
-	return false
+	return ""
 }
 
-func (x *RpcFindMatchRequest) GetAi() bool {
+func (x *OpponentDisconnected) GetDeadline() int64 {
 	if x != nil {
-		return x.Ai
+		return x.Deadline
 	}
-	return false
+	return 0
 }
 
-// Payload for an RPC response containing match IDs the user can join.
-type RpcFindMatchResponse struct {
+// A player intends to make a move.
+type Move struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// One or more matches that fit the user's request.
-	MatchIds []string `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
+	// The position the player wants to place their mark in.
+	Position int32 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
 }
 
-func (x *RpcFindMatchResponse) Reset() {
-	*x = RpcFindMatchResponse{}
+func (x *Move) Reset() {
+	*x = Move{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_xoxoapi_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,13 +868,13 @@ func (x *RpcFindMatchResponse) Reset() {
 	}
 }
 
-func (x *RpcFindMatchResponse) String() string {
+func (x *Move) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RpcFindMatchResponse) ProtoMessage() {}
+func (*Move) ProtoMessage() {}
 
-func (x *RpcFindMatchResponse) ProtoReflect() protoreflect.Message {
+func (x *Move) ProtoReflect() protoreflect.Message {
 	mi := &file_xoxoapi_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -526,121 +886,3289 @@ func (x *RpcFindMatchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RpcFindMatchResponse.ProtoReflect.Descriptor instead.
-func (*RpcFindMatchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RpcFindMatchResponse) GetMatchIds() []string {
+func (x *Move) GetPosition() int32 {
 	if x != nil {
-		return x.MatchIds
+		return x.Position
 	}
-	return nil
+	return 0
 }
 
-var File_xoxoapi_proto protoreflect.FileDescriptor
+// Payload for an RPC request to find a match.
+type RpcFindMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_xoxoapi_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x78, 0x6f, 0x78, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x03, 0x61, 0x70, 0x69, 0x22, 0xd5, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1f,
-	0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12,
-	0x2b, 0x0a, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x04,
-	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
-	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
-	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x43, 0x0a, 0x0a, 0x4d, 0x61, 0x72, 0x6b, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72,
-	0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x64, 0x0a, 0x06,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
-	0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
-	0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69,
-	0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69,
-	0x6e, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62,
-	0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x06,
-	0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12,
-	0x29, 0x0a, 0x10, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x65,
-	0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
-	0x78, 0x74, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x22, 0x22, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x13, 0x52, 0x70, 0x63, 0x46, 0x69, 0x6e,
-	0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x61,
-	0x69, 0x22, 0x33, 0x0a, 0x14, 0x52, 0x70, 0x63, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x74,
-	0x63, 0x68, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x49, 0x64, 0x73, 0x2a, 0x34, 0x0a, 0x04, 0x4d, 0x61, 0x72, 0x6b, 0x12, 0x14,
-	0x0a, 0x10, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
-	0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x58, 0x10, 0x01,
-	0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x4f, 0x10, 0x02, 0x2a, 0xac, 0x01, 0x0a,
-	0x06, 0x4f, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x50, 0x43, 0x4f, 0x44,
-	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x10, 0x0a, 0x0c, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10,
-	0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41,
-	0x54, 0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x44,
-	0x4f, 0x4e, 0x45, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x4d, 0x4f, 0x56, 0x45, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45,
-	0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x4f,
-	0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x50, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x4c,
-	0x45, 0x46, 0x54, 0x10, 0x06, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x49, 0x4e, 0x56, 0x49, 0x54, 0x45, 0x5f, 0x41, 0x49, 0x10, 0x07, 0x42, 0x33, 0x5a, 0x31, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x65, 0x72, 0x6f, 0x69, 0x63,
-	0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6e, 0x61, 0x6b, 0x61, 0x6d, 0x61, 0x2d, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x2d, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2f, 0x61, 0x70, 0x69,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// User can choose a fast or normal speed match.
+	Fast bool `protobuf:"varint,1,opt,name=fast,proto3" json:"fast,omitempty"`
+	// User can choose whether to play with AI
+	Ai bool `protobuf:"varint,2,opt,name=ai,proto3" json:"ai,omitempty"`
+	// Number of columns on the board. Defaults to 3 if not set.
+	Width int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	// Number of rows on the board. Defaults to 3 if not set.
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// How many marks in a row are needed to win. Defaults to 3 if not set.
+	WinLength int32 `protobuf:"varint,5,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// Number of rounds in a best-of series: 1, 3, 5 or 7. Defaults to a single round if not set.
+	SeriesLength int32 `protobuf:"varint,6,opt,name=series_length,json=seriesLength,proto3" json:"series_length,omitempty"`
+	// How well the AI plays, if playing with AI.
+	Difficulty Difficulty `protobuf:"varint,7,opt,name=difficulty,proto3,enum=api.Difficulty" json:"difficulty,omitempty"`
 }
 
-var (
-	file_xoxoapi_proto_rawDescOnce sync.Once
-	file_xoxoapi_proto_rawDescData = file_xoxoapi_proto_rawDesc
-)
-
-func file_xoxoapi_proto_rawDescGZIP() []byte {
+func (x *RpcFindMatchRequest) Reset() {
+	*x = RpcFindMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcFindMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcFindMatchRequest) ProtoMessage() {}
+
+func (x *RpcFindMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcFindMatchRequest.ProtoReflect.Descriptor instead.
+func (*RpcFindMatchRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RpcFindMatchRequest) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *RpcFindMatchRequest) GetAi() bool {
+	if x != nil {
+		return x.Ai
+	}
+	return false
+}
+
+func (x *RpcFindMatchRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetSeriesLength() int32 {
+	if x != nil {
+		return x.SeriesLength
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetDifficulty() Difficulty {
+	if x != nil {
+		return x.Difficulty
+	}
+	return Difficulty_DIFFICULTY_UNSPECIFIED
+}
+
+// Payload for an RPC response containing match IDs the user can join.
+type RpcFindMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One or more matches that fit the user's request.
+	MatchIds []string `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
+}
+
+func (x *RpcFindMatchResponse) Reset() {
+	*x = RpcFindMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcFindMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcFindMatchResponse) ProtoMessage() {}
+
+func (x *RpcFindMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcFindMatchResponse.ProtoReflect.Descriptor instead.
+func (*RpcFindMatchResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RpcFindMatchResponse) GetMatchIds() []string {
+	if x != nil {
+		return x.MatchIds
+	}
+	return nil
+}
+
+// Payload for an RPC request to list matches currently being played, which can be joined as a spectator.
+type RpcListLiveMatchesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of matches to return. Defaults to 10 if not set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *RpcListLiveMatchesRequest) Reset() {
+	*x = RpcListLiveMatchesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListLiveMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListLiveMatchesRequest) ProtoMessage() {}
+
+func (x *RpcListLiveMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListLiveMatchesRequest.ProtoReflect.Descriptor instead.
+func (*RpcListLiveMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RpcListLiveMatchesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A match currently being played, advertised to spectators.
+type LiveMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match ID to join with the "spectate" metadata flag set.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// Number of spectators currently watching.
+	Spectators int32 `protobuf:"varint,2,opt,name=spectators,proto3" json:"spectators,omitempty"`
+	// Whether it's a fast or normal speed match.
+	Fast bool `protobuf:"varint,3,opt,name=fast,proto3" json:"fast,omitempty"`
+	// Number of columns on the board.
+	Width int32 `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	// Number of rows on the board.
+	Height int32 `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	// How many marks in a row are needed to win.
+	WinLength int32 `protobuf:"varint,6,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+}
+
+func (x *LiveMatch) Reset() {
+	*x = LiveMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiveMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveMatch) ProtoMessage() {}
+
+func (x *LiveMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveMatch.ProtoReflect.Descriptor instead.
+func (*LiveMatch) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LiveMatch) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *LiveMatch) GetSpectators() int32 {
+	if x != nil {
+		return x.Spectators
+	}
+	return 0
+}
+
+func (x *LiveMatch) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *LiveMatch) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *LiveMatch) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *LiveMatch) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+// Payload for an RPC response listing live matches.
+type RpcListLiveMatchesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Matches currently being played, most watched first.
+	Matches []*LiveMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+}
+
+func (x *RpcListLiveMatchesResponse) Reset() {
+	*x = RpcListLiveMatchesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListLiveMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListLiveMatchesResponse) ProtoMessage() {}
+
+func (x *RpcListLiveMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListLiveMatchesResponse.ProtoReflect.Descriptor instead.
+func (*RpcListLiveMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RpcListLiveMatchesResponse) GetMatches() []*LiveMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+// Payload for an RPC request to export recorded games as AI training data. Only available server to server.
+type RpcExportTrainingDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// File format of the exported data.
+	Format TrainingDataFormat `protobuf:"varint,1,opt,name=format,proto3,enum=api.TrainingDataFormat" json:"format,omitempty"`
+	// Replace user IDs with a stable hash of them.
+	Anonymise bool `protobuf:"varint,2,opt,name=anonymise,proto3" json:"anonymise,omitempty"`
+	// Maximum number of games to export. Defaults to 100 if not set.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor returned by a previous export, to carry on from where it stopped.
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcExportTrainingDataRequest) Reset() {
+	*x = RpcExportTrainingDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcExportTrainingDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcExportTrainingDataRequest) ProtoMessage() {}
+
+func (x *RpcExportTrainingDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcExportTrainingDataRequest.ProtoReflect.Descriptor instead.
+func (*RpcExportTrainingDataRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RpcExportTrainingDataRequest) GetFormat() TrainingDataFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TrainingDataFormat_TRAINING_DATA_FORMAT_UNSPECIFIED
+}
+
+func (x *RpcExportTrainingDataRequest) GetAnonymise() bool {
+	if x != nil {
+		return x.Anonymise
+	}
+	return false
+}
+
+func (x *RpcExportTrainingDataRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RpcExportTrainingDataRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Payload for an RPC response with exported training data.
+type RpcExportTrainingDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The exported examples, one for every move a human player made.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Number of games exported.
+	Games int32 `protobuf:"varint,2,opt,name=games,proto3" json:"games,omitempty"`
+	// Number of examples exported.
+	Examples int32 `protobuf:"varint,3,opt,name=examples,proto3" json:"examples,omitempty"`
+	// Cursor to export the next games with, empty once there are no more.
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcExportTrainingDataResponse) Reset() {
+	*x = RpcExportTrainingDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcExportTrainingDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcExportTrainingDataResponse) ProtoMessage() {}
+
+func (x *RpcExportTrainingDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcExportTrainingDataResponse.ProtoReflect.Descriptor instead.
+func (*RpcExportTrainingDataResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RpcExportTrainingDataResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *RpcExportTrainingDataResponse) GetGames() int32 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+func (x *RpcExportTrainingDataResponse) GetExamples() int32 {
+	if x != nil {
+		return x.Examples
+	}
+	return 0
+}
+
+func (x *RpcExportTrainingDataResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Payload for an RPC request to list the caller's finished games.
+type RpcListMatchHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of games to return. Defaults to 10 if not set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor returned by a previous request, to list the next page of games.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcListMatchHistoryRequest) Reset() {
+	*x = RpcListMatchHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListMatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListMatchHistoryRequest) ProtoMessage() {}
+
+func (x *RpcListMatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListMatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*RpcListMatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RpcListMatchHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RpcListMatchHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// A player in a finished game.
+type MatchHistoryPlayer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The player's user ID.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The player's username.
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// The mark they played with.
+	Mark Mark `protobuf:"varint,3,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
+	// Their rating going into the game.
+	Rating float64 `protobuf:"fixed64,4,opt,name=rating,proto3" json:"rating,omitempty"`
+}
+
+func (x *MatchHistoryPlayer) Reset() {
+	*x = MatchHistoryPlayer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchHistoryPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryPlayer) ProtoMessage() {}
+
+func (x *MatchHistoryPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryPlayer.ProtoReflect.Descriptor instead.
+func (*MatchHistoryPlayer) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MatchHistoryPlayer) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MatchHistoryPlayer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *MatchHistoryPlayer) GetMark() Mark {
+	if x != nil {
+		return x.Mark
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *MatchHistoryPlayer) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+// A move played in a finished game.
+type MatchHistoryMove struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The board position played.
+	Position int32 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	// The mark of the player who played it.
+	Mark Mark `protobuf:"varint,2,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
+	// When it was played, in Unix milliseconds.
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *MatchHistoryMove) Reset() {
+	*x = MatchHistoryMove{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchHistoryMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryMove) ProtoMessage() {}
+
+func (x *MatchHistoryMove) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryMove.ProtoReflect.Descriptor instead.
+func (*MatchHistoryMove) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MatchHistoryMove) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *MatchHistoryMove) GetMark() Mark {
+	if x != nil {
+		return x.Mark
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *MatchHistoryMove) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// A finished game, one round of its match.
+type MatchHistoryGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match the game was played in.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The round of the match's series.
+	Round int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	// Whether it was a fast or normal speed match.
+	Fast bool `protobuf:"varint,3,opt,name=fast,proto3" json:"fast,omitempty"`
+	// Whether it was a ranked match.
+	Ranked bool `protobuf:"varint,4,opt,name=ranked,proto3" json:"ranked,omitempty"`
+	// Number of columns on the board.
+	Width int32 `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	// Number of rows on the board.
+	Height int32 `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	// How many marks in a row were needed to win.
+	WinLength int32 `protobuf:"varint,7,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// Both players.
+	Players []*MatchHistoryPlayer `protobuf:"bytes,8,rep,name=players,proto3" json:"players,omitempty"`
+	// Every move, in the order they were played.
+	Moves []*MatchHistoryMove `protobuf:"bytes,9,rep,name=moves,proto3" json:"moves,omitempty"`
+	// The winner's mark, unspecified for a draw.
+	Winner Mark `protobuf:"varint,10,opt,name=winner,proto3,enum=api.Mark" json:"winner,omitempty"`
+	// How the game ended.
+	Reason GameEndReason `protobuf:"varint,11,opt,name=reason,proto3,enum=api.GameEndReason" json:"reason,omitempty"`
+	// When the game started, in Unix milliseconds.
+	StartedAt int64 `protobuf:"varint,12,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// When the game ended, in Unix milliseconds.
+	EndedAt int64 `protobuf:"varint,13,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	// ID to watch the game again with, using the get_replay or watch_replay RPCs. It can be shared with others.
+	ReplayId string `protobuf:"bytes,14,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+}
+
+func (x *MatchHistoryGame) Reset() {
+	*x = MatchHistoryGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchHistoryGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryGame) ProtoMessage() {}
+
+func (x *MatchHistoryGame) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryGame.ProtoReflect.Descriptor instead.
+func (*MatchHistoryGame) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MatchHistoryGame) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchHistoryGame) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MatchHistoryGame) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *MatchHistoryGame) GetRanked() bool {
+	if x != nil {
+		return x.Ranked
+	}
+	return false
+}
+
+func (x *MatchHistoryGame) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MatchHistoryGame) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MatchHistoryGame) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *MatchHistoryGame) GetPlayers() []*MatchHistoryPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *MatchHistoryGame) GetMoves() []*MatchHistoryMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *MatchHistoryGame) GetWinner() Mark {
+	if x != nil {
+		return x.Winner
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *MatchHistoryGame) GetReason() GameEndReason {
+	if x != nil {
+		return x.Reason
+	}
+	return GameEndReason_GAME_END_REASON_UNSPECIFIED
+}
+
+func (x *MatchHistoryGame) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *MatchHistoryGame) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+func (x *MatchHistoryGame) GetReplayId() string {
+	if x != nil {
+		return x.ReplayId
+	}
+	return ""
+}
+
+// Payload for an RPC response listing the caller's finished games.
+type RpcListMatchHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Finished games, most recent first.
+	Games []*MatchHistoryGame `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	// Cursor to list the next page of games with, empty once there are no more.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcListMatchHistoryResponse) Reset() {
+	*x = RpcListMatchHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListMatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListMatchHistoryResponse) ProtoMessage() {}
+
+func (x *RpcListMatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListMatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*RpcListMatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RpcListMatchHistoryResponse) GetGames() []*MatchHistoryGame {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+func (x *RpcListMatchHistoryResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Payload for an RPC request to get a finished game to replay.
+type RpcGetReplayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The replay_id of a game from the match history.
+	ReplayId string `protobuf:"bytes,1,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+}
+
+func (x *RpcGetReplayRequest) Reset() {
+	*x = RpcGetReplayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetReplayRequest) ProtoMessage() {}
+
+func (x *RpcGetReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetReplayRequest.ProtoReflect.Descriptor instead.
+func (*RpcGetReplayRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RpcGetReplayRequest) GetReplayId() string {
+	if x != nil {
+		return x.ReplayId
+	}
+	return ""
+}
+
+// The state of the board at one point of a replay.
+type ReplayFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Milliseconds since the start of the game.
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The position just played, or -1 in the first frame.
+	Position int32 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	// The board and whose turn it is, as it was sent to the players.
+	Update *Update `protobuf:"bytes,3,opt,name=update,proto3" json:"update,omitempty"`
+}
+
+func (x *ReplayFrame) Reset() {
+	*x = ReplayFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayFrame) ProtoMessage() {}
+
+func (x *ReplayFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayFrame.ProtoReflect.Descriptor instead.
+func (*ReplayFrame) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReplayFrame) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReplayFrame) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ReplayFrame) GetUpdate() *Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+// Payload for an RPC response with a finished game to replay.
+type RpcGetReplayResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The game being replayed.
+	Game *MatchHistoryGame `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	// The board before the first move, then after every move.
+	Frames []*ReplayFrame `protobuf:"bytes,2,rep,name=frames,proto3" json:"frames,omitempty"`
+	// How the game ended, as it was sent to the players.
+	Done *Done `protobuf:"bytes,3,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (x *RpcGetReplayResponse) Reset() {
+	*x = RpcGetReplayResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetReplayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetReplayResponse) ProtoMessage() {}
+
+func (x *RpcGetReplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetReplayResponse.ProtoReflect.Descriptor instead.
+func (*RpcGetReplayResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RpcGetReplayResponse) GetGame() *MatchHistoryGame {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+func (x *RpcGetReplayResponse) GetFrames() []*ReplayFrame {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+func (x *RpcGetReplayResponse) GetDone() *Done {
+	if x != nil {
+		return x.Done
+	}
+	return nil
+}
+
+// Payload for an RPC request to watch a finished game replayed over the realtime socket.
+type RpcWatchReplayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The replay_id of a game from the match history.
+	ReplayId string `protobuf:"bytes,1,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+	// How many times faster than the game was played to replay it: 1, 2, 4 or 8. Defaults to 1 if not set.
+	Speed int32 `protobuf:"varint,2,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *RpcWatchReplayRequest) Reset() {
+	*x = RpcWatchReplayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcWatchReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcWatchReplayRequest) ProtoMessage() {}
+
+func (x *RpcWatchReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcWatchReplayRequest.ProtoReflect.Descriptor instead.
+func (*RpcWatchReplayRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RpcWatchReplayRequest) GetReplayId() string {
+	if x != nil {
+		return x.ReplayId
+	}
+	return ""
+}
+
+func (x *RpcWatchReplayRequest) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+// Payload for an RPC response with a replay match to join.
+type RpcWatchReplayResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match ID to join. The replay starts as soon as someone joins.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *RpcWatchReplayResponse) Reset() {
+	*x = RpcWatchReplayResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcWatchReplayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcWatchReplayResponse) ProtoMessage() {}
+
+func (x *RpcWatchReplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcWatchReplayResponse.ProtoReflect.Descriptor instead.
+func (*RpcWatchReplayResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RpcWatchReplayResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+// Payload for an RPC request to export a finished game in the game notation.
+type RpcExportGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The replay_id of a game from the match history.
+	ReplayId string `protobuf:"bytes,1,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+}
+
+func (x *RpcExportGameRequest) Reset() {
+	*x = RpcExportGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcExportGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcExportGameRequest) ProtoMessage() {}
+
+func (x *RpcExportGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcExportGameRequest.ProtoReflect.Descriptor instead.
+func (*RpcExportGameRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RpcExportGameRequest) GetReplayId() string {
+	if x != nil {
+		return x.ReplayId
+	}
+	return ""
+}
+
+// Payload for an RPC response containing a game in the game notation.
+type RpcExportGameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The game as text, with its headers and moves.
+	Notation string `protobuf:"bytes,1,opt,name=notation,proto3" json:"notation,omitempty"`
+}
+
+func (x *RpcExportGameResponse) Reset() {
+	*x = RpcExportGameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcExportGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcExportGameResponse) ProtoMessage() {}
+
+func (x *RpcExportGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcExportGameResponse.ProtoReflect.Descriptor instead.
+func (*RpcExportGameResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RpcExportGameResponse) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+// Payload for an RPC request to read a game written in the game notation.
+type RpcImportGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The game as text, with its headers and moves.
+	Notation string `protobuf:"bytes,1,opt,name=notation,proto3" json:"notation,omitempty"`
+}
+
+func (x *RpcImportGameRequest) Reset() {
+	*x = RpcImportGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcImportGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcImportGameRequest) ProtoMessage() {}
+
+func (x *RpcImportGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcImportGameRequest.ProtoReflect.Descriptor instead.
+func (*RpcImportGameRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RpcImportGameRequest) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+// Payload for an RPC request to get a player's statistics.
+type RpcGetPlayerStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The player to get statistics for, the caller if empty.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *RpcGetPlayerStatsRequest) Reset() {
+	*x = RpcGetPlayerStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetPlayerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetPlayerStatsRequest) ProtoMessage() {}
+
+func (x *RpcGetPlayerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetPlayerStatsRequest.ProtoReflect.Descriptor instead.
+func (*RpcGetPlayerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RpcGetPlayerStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// A player's results in one mode of play.
+type ModeStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the games were played with the fast turn clock.
+	Fast bool `protobuf:"varint,1,opt,name=fast,proto3" json:"fast,omitempty"`
+	// Whether the games were played against the AI.
+	Ai     bool  `protobuf:"varint,2,opt,name=ai,proto3" json:"ai,omitempty"`
+	Wins   int32 `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses int32 `protobuf:"varint,4,opt,name=losses,proto3" json:"losses,omitempty"`
+	Ties   int32 `protobuf:"varint,5,opt,name=ties,proto3" json:"ties,omitempty"`
+}
+
+func (x *ModeStats) Reset() {
+	*x = ModeStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeStats) ProtoMessage() {}
+
+func (x *ModeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeStats.ProtoReflect.Descriptor instead.
+func (*ModeStats) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ModeStats) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *ModeStats) GetAi() bool {
+	if x != nil {
+		return x.Ai
+	}
+	return false
+}
+
+func (x *ModeStats) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *ModeStats) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *ModeStats) GetTies() int32 {
+	if x != nil {
+		return x.Ties
+	}
+	return 0
+}
+
+// Payload for an RPC response containing a player's statistics, counted over every game they finished.
+type RpcGetPlayerStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Totals across every mode.
+	Wins   int32 `protobuf:"varint,2,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses int32 `protobuf:"varint,3,opt,name=losses,proto3" json:"losses,omitempty"`
+	Ties   int32 `protobuf:"varint,4,opt,name=ties,proto3" json:"ties,omitempty"`
+	// Results in each mode the player has played.
+	Modes []*ModeStats `protobuf:"bytes,5,rep,name=modes,proto3" json:"modes,omitempty"`
+	// Games won in a row up to the last one, and the most ever won in a row.
+	CurrentStreak int32 `protobuf:"varint,6,opt,name=current_streak,json=currentStreak,proto3" json:"current_streak,omitempty"`
+	BestStreak    int32 `protobuf:"varint,7,opt,name=best_streak,json=bestStreak,proto3" json:"best_streak,omitempty"`
+	// Average time the player took over a move, in milliseconds.
+	AverageMoveTime int64 `protobuf:"varint,8,opt,name=average_move_time,json=averageMoveTime,proto3" json:"average_move_time,omitempty"`
+	// Games in which the player moved first, and how many of them they won.
+	FirstMoveGames int32 `protobuf:"varint,9,opt,name=first_move_games,json=firstMoveGames,proto3" json:"first_move_games,omitempty"`
+	FirstMoveWins  int32 `protobuf:"varint,10,opt,name=first_move_wins,json=firstMoveWins,proto3" json:"first_move_wins,omitempty"`
+	// The share of games moving first that the player won, between 0 and 1.
+	FirstMoveWinRate float64 `protobuf:"fixed64,11,opt,name=first_move_win_rate,json=firstMoveWinRate,proto3" json:"first_move_win_rate,omitempty"`
+}
+
+func (x *RpcGetPlayerStatsResponse) Reset() {
+	*x = RpcGetPlayerStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetPlayerStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetPlayerStatsResponse) ProtoMessage() {}
+
+func (x *RpcGetPlayerStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetPlayerStatsResponse.ProtoReflect.Descriptor instead.
+func (*RpcGetPlayerStatsResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RpcGetPlayerStatsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RpcGetPlayerStatsResponse) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetTies() int32 {
+	if x != nil {
+		return x.Ties
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetModes() []*ModeStats {
+	if x != nil {
+		return x.Modes
+	}
+	return nil
+}
+
+func (x *RpcGetPlayerStatsResponse) GetCurrentStreak() int32 {
+	if x != nil {
+		return x.CurrentStreak
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetBestStreak() int32 {
+	if x != nil {
+		return x.BestStreak
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetAverageMoveTime() int64 {
+	if x != nil {
+		return x.AverageMoveTime
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetFirstMoveGames() int32 {
+	if x != nil {
+		return x.FirstMoveGames
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetFirstMoveWins() int32 {
+	if x != nil {
+		return x.FirstMoveWins
+	}
+	return 0
+}
+
+func (x *RpcGetPlayerStatsResponse) GetFirstMoveWinRate() float64 {
+	if x != nil {
+		return x.FirstMoveWinRate
+	}
+	return 0
+}
+
+// Payload for an RPC request to list the results of past seasons.
+type RpcListSeasonResultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of seasons to return, 10 if not set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor returned by the previous page, if any.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Only list seasons of this leaderboard, tictactoe_weekly or tictactoe_season. Both if empty.
+	LeaderboardId string `protobuf:"bytes,3,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+}
+
+func (x *RpcListSeasonResultsRequest) Reset() {
+	*x = RpcListSeasonResultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListSeasonResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListSeasonResultsRequest) ProtoMessage() {}
+
+func (x *RpcListSeasonResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListSeasonResultsRequest.ProtoReflect.Descriptor instead.
+func (*RpcListSeasonResultsRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RpcListSeasonResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RpcListSeasonResultsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *RpcListSeasonResultsRequest) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+// A player's final standing in a season, and what they were awarded for it.
+type SeasonRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank     int64  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	UserId   string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// Ranked series won during the season.
+	Score int64 `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	// Coins added to the player's wallet.
+	Coins int64 `protobuf:"varint,5,opt,name=coins,proto3" json:"coins,omitempty"`
+	// The badge earned, if any.
+	Badge string `protobuf:"bytes,6,opt,name=badge,proto3" json:"badge,omitempty"`
+}
+
+func (x *SeasonRecord) Reset() {
+	*x = SeasonRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeasonRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeasonRecord) ProtoMessage() {}
+
+func (x *SeasonRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeasonRecord.ProtoReflect.Descriptor instead.
+func (*SeasonRecord) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SeasonRecord) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SeasonRecord) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SeasonRecord) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SeasonRecord) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SeasonRecord) GetCoins() int64 {
+	if x != nil {
+		return x.Coins
+	}
+	return 0
+}
+
+func (x *SeasonRecord) GetBadge() string {
+	if x != nil {
+		return x.Badge
+	}
+	return ""
+}
+
+// The final standings of a weekly or quarterly season.
+type SeasonResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The leaderboard the season was played on, tictactoe_weekly or tictactoe_season.
+	LeaderboardId string `protobuf:"bytes,1,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	// When the season ended, in Unix seconds.
+	EndedAt int64 `protobuf:"varint,2,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	// The players at the top of the leaderboard when it reset, best first.
+	Records []*SeasonRecord `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"`
+	// The caller's own standing, if they were among the records.
+	OwnRecord *SeasonRecord `protobuf:"bytes,4,opt,name=own_record,json=ownRecord,proto3" json:"own_record,omitempty"`
+}
+
+func (x *SeasonResult) Reset() {
+	*x = SeasonResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeasonResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeasonResult) ProtoMessage() {}
+
+func (x *SeasonResult) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeasonResult.ProtoReflect.Descriptor instead.
+func (*SeasonResult) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SeasonResult) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *SeasonResult) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+func (x *SeasonResult) GetRecords() []*SeasonRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *SeasonResult) GetOwnRecord() *SeasonRecord {
+	if x != nil {
+		return x.OwnRecord
+	}
+	return nil
+}
+
+// Payload for an RPC response listing past seasons, most recent first.
+type RpcListSeasonResultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seasons []*SeasonResult `protobuf:"bytes,1,rep,name=seasons,proto3" json:"seasons,omitempty"`
+	// Cursor for the next page, empty if there are no more.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcListSeasonResultsResponse) Reset() {
+	*x = RpcListSeasonResultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListSeasonResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListSeasonResultsResponse) ProtoMessage() {}
+
+func (x *RpcListSeasonResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListSeasonResultsResponse.ProtoReflect.Descriptor instead.
+func (*RpcListSeasonResultsResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RpcListSeasonResultsResponse) GetSeasons() []*SeasonResult {
+	if x != nil {
+		return x.Seasons
+	}
+	return nil
+}
+
+func (x *RpcListSeasonResultsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Payload for an RPC request to view a leaderboard around the caller, among their friends, or in a country.
+type RpcLeaderboardViewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The leaderboard to view, tictactoe_ranked_normal if empty.
+	LeaderboardId string `protobuf:"bytes,1,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	// Number of records to return, 20 if not set.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Country code to rank, the caller's own country if empty. Only used by the country view.
+	Country string `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+}
+
+func (x *RpcLeaderboardViewRequest) Reset() {
+	*x = RpcLeaderboardViewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcLeaderboardViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcLeaderboardViewRequest) ProtoMessage() {}
+
+func (x *RpcLeaderboardViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcLeaderboardViewRequest.ProtoReflect.Descriptor instead.
+func (*RpcLeaderboardViewRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RpcLeaderboardViewRequest) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *RpcLeaderboardViewRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RpcLeaderboardViewRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// A player's place on a leaderboard view.
+type LeaderboardViewRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Score    int64  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	// Rank within the view: on the whole leaderboard around the caller, otherwise among friends or the country.
+	Rank int64 `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+}
+
+func (x *LeaderboardViewRecord) Reset() {
+	*x = LeaderboardViewRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardViewRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardViewRecord) ProtoMessage() {}
+
+func (x *LeaderboardViewRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardViewRecord.ProtoReflect.Descriptor instead.
+func (*LeaderboardViewRecord) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LeaderboardViewRecord) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardViewRecord) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardViewRecord) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LeaderboardViewRecord) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// Payload for an RPC response containing a leaderboard view.
+type RpcLeaderboardViewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LeaderboardId string `protobuf:"bytes,1,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	// Records in rank order.
+	Records []*LeaderboardViewRecord `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	// The caller's own record, if they are on the leaderboard.
+	OwnRecord *LeaderboardViewRecord `protobuf:"bytes,3,opt,name=own_record,json=ownRecord,proto3" json:"own_record,omitempty"`
+	// The caller's own stats.
+	OwnStats *RpcGetPlayerStatsResponse `protobuf:"bytes,4,opt,name=own_stats,json=ownStats,proto3" json:"own_stats,omitempty"`
+	// The country ranked, for the country view.
+	Country string `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+}
+
+func (x *RpcLeaderboardViewResponse) Reset() {
+	*x = RpcLeaderboardViewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcLeaderboardViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcLeaderboardViewResponse) ProtoMessage() {}
+
+func (x *RpcLeaderboardViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcLeaderboardViewResponse.ProtoReflect.Descriptor instead.
+func (*RpcLeaderboardViewResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RpcLeaderboardViewResponse) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *RpcLeaderboardViewResponse) GetRecords() []*LeaderboardViewRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *RpcLeaderboardViewResponse) GetOwnRecord() *LeaderboardViewRecord {
+	if x != nil {
+		return x.OwnRecord
+	}
+	return nil
+}
+
+func (x *RpcLeaderboardViewResponse) GetOwnStats() *RpcGetPlayerStatsResponse {
+	if x != nil {
+		return x.OwnStats
+	}
+	return nil
+}
+
+func (x *RpcLeaderboardViewResponse) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// Payload for an RPC request to create a private match, joined with a room code.
+type RpcCreatePrivateMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether to use the fast turn clock.
+	Fast bool `protobuf:"varint,1,opt,name=fast,proto3" json:"fast,omitempty"`
+	// Board dimensions, 3x3 if not set.
+	Width  int32 `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// Marks in a row needed to win, 3 if not set.
+	WinLength int32 `protobuf:"varint,4,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// Number of rounds in the series: 1, 3, 5 or 7. A single round if not set.
+	SeriesLength int32 `protobuf:"varint,5,opt,name=series_length,json=seriesLength,proto3" json:"series_length,omitempty"`
+}
+
+func (x *RpcCreatePrivateMatchRequest) Reset() {
+	*x = RpcCreatePrivateMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcCreatePrivateMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcCreatePrivateMatchRequest) ProtoMessage() {}
+
+func (x *RpcCreatePrivateMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcCreatePrivateMatchRequest.ProtoReflect.Descriptor instead.
+func (*RpcCreatePrivateMatchRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetSeriesLength() int32 {
+	if x != nil {
+		return x.SeriesLength
+	}
+	return 0
+}
+
+// Payload for an RPC response containing a new private match.
+type RpcCreatePrivateMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match ID to join, with the code in the join metadata.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The room code to share with the other player.
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *RpcCreatePrivateMatchResponse) Reset() {
+	*x = RpcCreatePrivateMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcCreatePrivateMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcCreatePrivateMatchResponse) ProtoMessage() {}
+
+func (x *RpcCreatePrivateMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcCreatePrivateMatchResponse.ProtoReflect.Descriptor instead.
+func (*RpcCreatePrivateMatchResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RpcCreatePrivateMatchResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RpcCreatePrivateMatchResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Payload for an RPC request to find a private match by its room code.
+type RpcJoinPrivateMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The room code, in any case.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *RpcJoinPrivateMatchRequest) Reset() {
+	*x = RpcJoinPrivateMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcJoinPrivateMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcJoinPrivateMatchRequest) ProtoMessage() {}
+
+func (x *RpcJoinPrivateMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcJoinPrivateMatchRequest.ProtoReflect.Descriptor instead.
+func (*RpcJoinPrivateMatchRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RpcJoinPrivateMatchRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Payload for an RPC response containing the private match a room code refers to.
+type RpcJoinPrivateMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match ID to join, with the code in the join metadata.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *RpcJoinPrivateMatchResponse) Reset() {
+	*x = RpcJoinPrivateMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcJoinPrivateMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcJoinPrivateMatchResponse) ProtoMessage() {}
+
+func (x *RpcJoinPrivateMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcJoinPrivateMatchResponse.ProtoReflect.Descriptor instead.
+func (*RpcJoinPrivateMatchResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RpcJoinPrivateMatchResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+// A challenge from one player to a friend, to play in a match held for the two of them.
+type Challenge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match held for the challenge, which also identifies it.
+	MatchId            string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ChallengerId       string `protobuf:"bytes,2,opt,name=challenger_id,json=challengerId,proto3" json:"challenger_id,omitempty"`
+	ChallengerUsername string `protobuf:"bytes,3,opt,name=challenger_username,json=challengerUsername,proto3" json:"challenger_username,omitempty"`
+	OpponentId         string `protobuf:"bytes,4,opt,name=opponent_id,json=opponentId,proto3" json:"opponent_id,omitempty"`
+	// Whether the match uses the fast turn clock.
+	Fast         bool            `protobuf:"varint,5,opt,name=fast,proto3" json:"fast,omitempty"`
+	Width        int32           `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height       int32           `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	WinLength    int32           `protobuf:"varint,8,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	SeriesLength int32           `protobuf:"varint,9,opt,name=series_length,json=seriesLength,proto3" json:"series_length,omitempty"`
+	Status       ChallengeStatus `protobuf:"varint,10,opt,name=status,proto3,enum=api.ChallengeStatus" json:"status,omitempty"`
+	// When the friend has to join by, in Unix seconds.
+	ExpiresAt int64 `protobuf:"varint,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *Challenge) Reset() {
+	*x = Challenge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Challenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Challenge) ProtoMessage() {}
+
+func (x *Challenge) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Challenge.ProtoReflect.Descriptor instead.
+func (*Challenge) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Challenge) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Challenge) GetChallengerId() string {
+	if x != nil {
+		return x.ChallengerId
+	}
+	return ""
+}
+
+func (x *Challenge) GetChallengerUsername() string {
+	if x != nil {
+		return x.ChallengerUsername
+	}
+	return ""
+}
+
+func (x *Challenge) GetOpponentId() string {
+	if x != nil {
+		return x.OpponentId
+	}
+	return ""
+}
+
+func (x *Challenge) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *Challenge) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Challenge) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Challenge) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *Challenge) GetSeriesLength() int32 {
+	if x != nil {
+		return x.SeriesLength
+	}
+	return 0
+}
+
+func (x *Challenge) GetStatus() ChallengeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ChallengeStatus_CHALLENGE_STATUS_UNSPECIFIED
+}
+
+func (x *Challenge) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Payload for an RPC request to challenge a friend.
+type RpcChallengeFriendRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The friend to challenge.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Whether to use the fast turn clock.
+	Fast bool `protobuf:"varint,2,opt,name=fast,proto3" json:"fast,omitempty"`
+	// Board dimensions, 3x3 if not set.
+	Width  int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// Marks in a row needed to win, 3 if not set.
+	WinLength int32 `protobuf:"varint,5,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// Number of rounds in the series: 1, 3, 5 or 7. A single round if not set.
+	SeriesLength int32 `protobuf:"varint,6,opt,name=series_length,json=seriesLength,proto3" json:"series_length,omitempty"`
+}
+
+func (x *RpcChallengeFriendRequest) Reset() {
+	*x = RpcChallengeFriendRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcChallengeFriendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcChallengeFriendRequest) ProtoMessage() {}
+
+func (x *RpcChallengeFriendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcChallengeFriendRequest.ProtoReflect.Descriptor instead.
+func (*RpcChallengeFriendRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RpcChallengeFriendRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RpcChallengeFriendRequest) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *RpcChallengeFriendRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetSeriesLength() int32 {
+	if x != nil {
+		return x.SeriesLength
+	}
+	return 0
+}
+
+// Payload for an RPC request to accept or decline a challenge.
+type RpcRespondChallengeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match ID the challenge notification carries.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Accept  bool   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *RpcRespondChallengeRequest) Reset() {
+	*x = RpcRespondChallengeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcRespondChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcRespondChallengeRequest) ProtoMessage() {}
+
+func (x *RpcRespondChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcRespondChallengeRequest.ProtoReflect.Descriptor instead.
+func (*RpcRespondChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RpcRespondChallengeRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RpcRespondChallengeRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+// Payload for an RPC response containing the match the caller has a round in progress in.
+type RpcGetActiveMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match ID to rejoin, empty if there's none.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *RpcGetActiveMatchResponse) Reset() {
+	*x = RpcGetActiveMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetActiveMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetActiveMatchResponse) ProtoMessage() {}
+
+func (x *RpcGetActiveMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetActiveMatchResponse.ProtoReflect.Descriptor instead.
+func (*RpcGetActiveMatchResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RpcGetActiveMatchResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+var File_xoxoapi_proto protoreflect.FileDescriptor
+
+var file_xoxoapi_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x78, 0x6f, 0x78, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x03, 0x61, 0x70, 0x69, 0x22, 0xea, 0x03, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1f,
+	0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12,
+	0x2b, 0x0a, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x04,
+	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3e, 0x0a, 0x0c,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x53,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x1a, 0x43, 0x0a, 0x0a,
+	0x4d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x64, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x04,
+	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x8b, 0x03, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65,
+	0x12, 0x1f, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x21, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x06, 0x77, 0x69,
+	0x6e, 0x6e, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f,
+	0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69,
+	0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x44, 0x6f, 0x6e, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x6f,
+	0x76, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x4f, 0x76, 0x65, 0x72, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x41, 0x0a, 0x07, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x4b, 0x0a, 0x14, 0x4f, 0x70, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x22, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdc, 0x01, 0x0a, 0x13, 0x52, 0x70,
+	0x63, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x02, 0x61, 0x69, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69,
+	0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x52, 0x0a, 0x64, 0x69,
+	0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x22, 0x33, 0x0a, 0x14, 0x52, 0x70, 0x63, 0x46,
+	0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x73, 0x22, 0x31, 0x0a,
+	0x19, 0x52, 0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x76, 0x65, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0xa7, 0x01, 0x0a, 0x09, 0x4c, 0x69, 0x76, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19,
+	0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x73,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77,
+	0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x46, 0x0a, 0x1a, 0x52, 0x70,
+	0x63, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x76, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x76, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x1c, 0x52, 0x70, 0x63, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x54, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x72, 0x61, 0x69, 0x6e, 0x69,
+	0x6e, 0x67, 0x44, 0x61, 0x74, 0x61, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x73,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x22, 0x7d, 0x0a, 0x1d, 0x52, 0x70, 0x63, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x72, 0x61,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22,
+	0x4a, 0x0a, 0x1a, 0x52, 0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x80, 0x01, 0x0a, 0x12,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
+	0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x61,
+	0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f,
+	0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
+	0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x22, 0xc2, 0x03, 0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x61, 0x6e, 0x6b, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x61, 0x6e,
+	0x6b, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x31, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73,
+	0x12, 0x21, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x06, 0x77, 0x69, 0x6e,
+	0x6e, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x6e,
+	0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x70, 0x6c, 0x61, 0x79, 0x49, 0x64, 0x22, 0x62, 0x0a, 0x1b, 0x52, 0x70, 0x63, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x67, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x32, 0x0a, 0x13, 0x52, 0x70,
+	0x63, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x49, 0x64, 0x22, 0x66,
+	0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x23, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x8a, 0x01, 0x0a, 0x14, 0x52, 0x70, 0x63, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x29, 0x0a, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x47, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6e, 0x65, 0x52, 0x04, 0x64,
+	0x6f, 0x6e, 0x65, 0x22, 0x4a, 0x0a, 0x15, 0x52, 0x70, 0x63, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22,
+	0x33, 0x0a, 0x16, 0x52, 0x70, 0x63, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x61,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x14, 0x52, 0x70, 0x63, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x15, 0x52, 0x70, 0x63,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x32,
+	0x0a, 0x14, 0x52, 0x70, 0x63, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x18, 0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x6f, 0x0a, 0x09, 0x4d, 0x6f, 0x64, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x61, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x69, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x6f,
+	0x73, 0x73, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x74, 0x69, 0x65, 0x73, 0x22, 0x8f, 0x03, 0x0a, 0x19, 0x52, 0x70, 0x63,
+	0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x77,
+	0x69, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05,
+	0x6d, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x1f, 0x0a, 0x0b,
+	0x62, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x62, 0x65, 0x73, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x2a, 0x0a,
+	0x11, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67,
+	0x65, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x47, 0x61,
+	0x6d, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x76,
+	0x65, 0x5f, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x57, 0x69, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4d,
+	0x6f, 0x76, 0x65, 0x57, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x22, 0x72, 0x0a, 0x1b, 0x52, 0x70,
+	0x63, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x49, 0x64, 0x22, 0x99,
+	0x01, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x6f, 0x69, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x61, 0x64, 0x67, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x61, 0x64, 0x67, 0x65, 0x22, 0xaf, 0x01, 0x0a, 0x0c, 0x53,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b, 0x0a,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x6f, 0x77,
+	0x6e, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x09, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x63, 0x0a, 0x1c,
+	0x52, 0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x07,
+	0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x07, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x22, 0x72, 0x0a, 0x19, 0x52, 0x70, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
+	0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x76, 0x0a, 0x15, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e,
+	0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x22, 0x8b, 0x02,
+	0x0a, 0x1a, 0x52, 0x70, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x6f, 0x77, 0x6e,
+	0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x56,
+	0x69, 0x65, 0x77, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x09, 0x6f, 0x77, 0x6e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x3b, 0x0a, 0x09, 0x6f, 0x77, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x70,
+	0x63, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x22, 0xa4, 0x01, 0x0a, 0x1c,
+	0x52, 0x70, 0x63, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x22, 0x4e, 0x0a, 0x1d, 0x52, 0x70, 0x63, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x22, 0x30, 0x0a, 0x1a, 0x52, 0x70, 0x63, 0x4a, 0x6f, 0x69, 0x6e, 0x50, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x22, 0x38, 0x0a, 0x1b, 0x52, 0x70, 0x63, 0x4a, 0x6f, 0x69, 0x6e, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0xf0,
+	0x02, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x13,
+	0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x63, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41,
+	0x74, 0x22, 0xba, 0x01, 0x0a, 0x19, 0x52, 0x70, 0x63, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69,
+	0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x4f,
+	0x0a, 0x1a, 0x52, 0x70, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x43, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x22,
+	0x36, 0x0a, 0x19, 0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x2a, 0x34, 0x0a, 0x04, 0x4d, 0x61, 0x72, 0x6b, 0x12,
+	0x14, 0x0a, 0x10, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x58, 0x10,
+	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x4f, 0x10, 0x02, 0x2a, 0x81, 0x01,
+	0x0a, 0x0a, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x16,
+	0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x46, 0x46,
+	0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x45, 0x41, 0x53, 0x59, 0x10, 0x01, 0x12, 0x15, 0x0a,
+	0x11, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x4d, 0x45, 0x44, 0x49,
+	0x55, 0x4d, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c,
+	0x54, 0x59, 0x5f, 0x48, 0x41, 0x52, 0x44, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x44, 0x49, 0x46,
+	0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x50, 0x45, 0x52, 0x46, 0x45, 0x43, 0x54, 0x10,
+	0x04, 0x2a, 0xa0, 0x01, 0x0a, 0x0d, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x1b, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x45, 0x4e, 0x44, 0x5f,
+	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x45, 0x4e, 0x44,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x17,
+	0x0a, 0x13, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x45, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x54, 0x49, 0x45, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x47, 0x41, 0x4d, 0x45, 0x5f,
+	0x45, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x4f, 0x52, 0x46, 0x45,
+	0x49, 0x54, 0x10, 0x03, 0x12, 0x1e, 0x0a, 0x1a, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x45, 0x4e, 0x44,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
+	0x43, 0x54, 0x10, 0x04, 0x2a, 0x7d, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x44, 0x61, 0x74, 0x61, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x20, 0x54, 0x52,
+	0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x46, 0x4f, 0x52, 0x4d,
+	0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x52, 0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x41, 0x54,
+	0x41, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x4c, 0x10, 0x01,
+	0x12, 0x21, 0x0a, 0x1d, 0x54, 0x52, 0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x41, 0x54,
+	0x41, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x54, 0x46, 0x52, 0x45, 0x43, 0x4f, 0x52,
+	0x44, 0x10, 0x02, 0x2a, 0xc2, 0x02, 0x0a, 0x06, 0x4f, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x12, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45,
+	0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x50, 0x43, 0x4f,
+	0x44, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4f,
+	0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b,
+	0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x10, 0x04, 0x12, 0x13, 0x0a,
+	0x0f, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44,
+	0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x50, 0x50,
+	0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x06, 0x12, 0x14, 0x0a, 0x10,
+	0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x56, 0x49, 0x54, 0x45, 0x5f, 0x41, 0x49,
+	0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4d,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x08, 0x12, 0x19,
+	0x0a, 0x15, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x41, 0x54, 0x43, 0x48,
+	0x5f, 0x41, 0x43, 0x43, 0x45, 0x50, 0x54, 0x10, 0x09, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x50, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x44, 0x45, 0x43, 0x4c,
+	0x49, 0x4e, 0x45, 0x10, 0x0a, 0x12, 0x20, 0x0a, 0x1c, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x4f, 0x50, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e,
+	0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x1f, 0x0a, 0x1b, 0x4f, 0x50, 0x43, 0x4f, 0x44,
+	0x45, 0x5f, 0x4f, 0x50, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x4e,
+	0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x0c, 0x2a, 0xad, 0x01, 0x0a, 0x0f, 0x43, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x1c,
+	0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c,
+	0x0a, 0x18, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19,
+	0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x41, 0x43, 0x43, 0x45, 0x50, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x43,
+	0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x44, 0x45, 0x43, 0x4c, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x48,
+	0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45,
+	0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x04, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x65, 0x72, 0x6f, 0x69, 0x63, 0x6c, 0x61, 0x62,
+	0x73, 0x2f, 0x6e, 0x61, 0x6b, 0x61, 0x6d, 0x61, 0x2d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x2d, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_xoxoapi_proto_rawDescOnce sync.Once
+	file_xoxoapi_proto_rawDescData = file_xoxoapi_proto_rawDesc
+)
+
+func file_xoxoapi_proto_rawDescGZIP() []byte {
 	file_xoxoapi_proto_rawDescOnce.Do(func() {
 		file_xoxoapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_xoxoapi_proto_rawDescData)
 	})
 	return file_xoxoapi_proto_rawDescData
 }
 
-var file_xoxoapi_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_xoxoapi_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_xoxoapi_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_xoxoapi_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_xoxoapi_proto_goTypes = []interface{}{
-	(Mark)(0),                    // 0: api.Mark
-	(OpCode)(0),                  // 1: api.OpCode
-	(*Start)(nil),                // 2: api.Start
-	(*Update)(nil),               // 3: api.Update
-	(*Done)(nil),                 // 4: api.Done
-	(*Move)(nil),                 // 5: api.Move
-	(*RpcFindMatchRequest)(nil),  // 6: api.RpcFindMatchRequest
-	(*RpcFindMatchResponse)(nil), // 7: api.RpcFindMatchResponse
-	nil,                          // 8: api.Start.MarksEntry
+	(Mark)(0),                             // 0: api.Mark
+	(Difficulty)(0),                       // 1: api.Difficulty
+	(GameEndReason)(0),                    // 2: api.GameEndReason
+	(TrainingDataFormat)(0),               // 3: api.TrainingDataFormat
+	(OpCode)(0),                           // 4: api.OpCode
+	(ChallengeStatus)(0),                  // 5: api.ChallengeStatus
+	(*Start)(nil),                         // 6: api.Start
+	(*Update)(nil),                        // 7: api.Update
+	(*Done)(nil),                          // 8: api.Done
+	(*Rematch)(nil),                       // 9: api.Rematch
+	(*OpponentDisconnected)(nil),          // 10: api.OpponentDisconnected
+	(*Move)(nil),                          // 11: api.Move
+	(*RpcFindMatchRequest)(nil),           // 12: api.RpcFindMatchRequest
+	(*RpcFindMatchResponse)(nil),          // 13: api.RpcFindMatchResponse
+	(*RpcListLiveMatchesRequest)(nil),     // 14: api.RpcListLiveMatchesRequest
+	(*LiveMatch)(nil),                     // 15: api.LiveMatch
+	(*RpcListLiveMatchesResponse)(nil),    // 16: api.RpcListLiveMatchesResponse
+	(*RpcExportTrainingDataRequest)(nil),  // 17: api.RpcExportTrainingDataRequest
+	(*RpcExportTrainingDataResponse)(nil), // 18: api.RpcExportTrainingDataResponse
+	(*RpcListMatchHistoryRequest)(nil),    // 19: api.RpcListMatchHistoryRequest
+	(*MatchHistoryPlayer)(nil),            // 20: api.MatchHistoryPlayer
+	(*MatchHistoryMove)(nil),              // 21: api.MatchHistoryMove
+	(*MatchHistoryGame)(nil),              // 22: api.MatchHistoryGame
+	(*RpcListMatchHistoryResponse)(nil),   // 23: api.RpcListMatchHistoryResponse
+	(*RpcGetReplayRequest)(nil),           // 24: api.RpcGetReplayRequest
+	(*ReplayFrame)(nil),                   // 25: api.ReplayFrame
+	(*RpcGetReplayResponse)(nil),          // 26: api.RpcGetReplayResponse
+	(*RpcWatchReplayRequest)(nil),         // 27: api.RpcWatchReplayRequest
+	(*RpcWatchReplayResponse)(nil),        // 28: api.RpcWatchReplayResponse
+	(*RpcExportGameRequest)(nil),          // 29: api.RpcExportGameRequest
+	(*RpcExportGameResponse)(nil),         // 30: api.RpcExportGameResponse
+	(*RpcImportGameRequest)(nil),          // 31: api.RpcImportGameRequest
+	(*RpcGetPlayerStatsRequest)(nil),      // 32: api.RpcGetPlayerStatsRequest
+	(*ModeStats)(nil),                     // 33: api.ModeStats
+	(*RpcGetPlayerStatsResponse)(nil),     // 34: api.RpcGetPlayerStatsResponse
+	(*RpcListSeasonResultsRequest)(nil),   // 35: api.RpcListSeasonResultsRequest
+	(*SeasonRecord)(nil),                  // 36: api.SeasonRecord
+	(*SeasonResult)(nil),                  // 37: api.SeasonResult
+	(*RpcListSeasonResultsResponse)(nil),  // 38: api.RpcListSeasonResultsResponse
+	(*RpcLeaderboardViewRequest)(nil),     // 39: api.RpcLeaderboardViewRequest
+	(*LeaderboardViewRecord)(nil),         // 40: api.LeaderboardViewRecord
+	(*RpcLeaderboardViewResponse)(nil),    // 41: api.RpcLeaderboardViewResponse
+	(*RpcCreatePrivateMatchRequest)(nil),  // 42: api.RpcCreatePrivateMatchRequest
+	(*RpcCreatePrivateMatchResponse)(nil), // 43: api.RpcCreatePrivateMatchResponse
+	(*RpcJoinPrivateMatchRequest)(nil),    // 44: api.RpcJoinPrivateMatchRequest
+	(*RpcJoinPrivateMatchResponse)(nil),   // 45: api.RpcJoinPrivateMatchResponse
+	(*Challenge)(nil),                     // 46: api.Challenge
+	(*RpcChallengeFriendRequest)(nil),     // 47: api.RpcChallengeFriendRequest
+	(*RpcRespondChallengeRequest)(nil),    // 48: api.RpcRespondChallengeRequest
+	(*RpcGetActiveMatchResponse)(nil),     // 49: api.RpcGetActiveMatchResponse
+	nil,                                   // 50: api.Start.MarksEntry
+	nil,                                   // 51: api.Start.SeriesScoreEntry
+	nil,                                   // 52: api.Done.SeriesScoreEntry
 }
 var file_xoxoapi_proto_depIdxs = []int32{
-	0, // 0: api.Start.board:type_name -> api.Mark
-	8, // 1: api.Start.marks:type_name -> api.Start.MarksEntry
-	0, // 2: api.Start.mark:type_name -> api.Mark
-	0, // 3: api.Update.board:type_name -> api.Mark
-	0, // 4: api.Update.mark:type_name -> api.Mark
-	0, // 5: api.Done.board:type_name -> api.Mark
-	0, // 6: api.Done.winner:type_name -> api.Mark
-	0, // 7: api.Start.MarksEntry.value:type_name -> api.Mark
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	0,  // 0: api.Start.board:type_name -> api.Mark
+	50, // 1: api.Start.marks:type_name -> api.Start.MarksEntry
+	0,  // 2: api.Start.mark:type_name -> api.Mark
+	51, // 3: api.Start.series_score:type_name -> api.Start.SeriesScoreEntry
+	0,  // 4: api.Update.board:type_name -> api.Mark
+	0,  // 5: api.Update.mark:type_name -> api.Mark
+	0,  // 6: api.Done.board:type_name -> api.Mark
+	0,  // 7: api.Done.winner:type_name -> api.Mark
+	52, // 8: api.Done.series_score:type_name -> api.Done.SeriesScoreEntry
+	1,  // 9: api.RpcFindMatchRequest.difficulty:type_name -> api.Difficulty
+	15, // 10: api.RpcListLiveMatchesResponse.matches:type_name -> api.LiveMatch
+	3,  // 11: api.RpcExportTrainingDataRequest.format:type_name -> api.TrainingDataFormat
+	0,  // 12: api.MatchHistoryPlayer.mark:type_name -> api.Mark
+	0,  // 13: api.MatchHistoryMove.mark:type_name -> api.Mark
+	20, // 14: api.MatchHistoryGame.players:type_name -> api.MatchHistoryPlayer
+	21, // 15: api.MatchHistoryGame.moves:type_name -> api.MatchHistoryMove
+	0,  // 16: api.MatchHistoryGame.winner:type_name -> api.Mark
+	2,  // 17: api.MatchHistoryGame.reason:type_name -> api.GameEndReason
+	22, // 18: api.RpcListMatchHistoryResponse.games:type_name -> api.MatchHistoryGame
+	7,  // 19: api.ReplayFrame.update:type_name -> api.Update
+	22, // 20: api.RpcGetReplayResponse.game:type_name -> api.MatchHistoryGame
+	25, // 21: api.RpcGetReplayResponse.frames:type_name -> api.ReplayFrame
+	8,  // 22: api.RpcGetReplayResponse.done:type_name -> api.Done
+	33, // 23: api.RpcGetPlayerStatsResponse.modes:type_name -> api.ModeStats
+	36, // 24: api.SeasonResult.records:type_name -> api.SeasonRecord
+	36, // 25: api.SeasonResult.own_record:type_name -> api.SeasonRecord
+	37, // 26: api.RpcListSeasonResultsResponse.seasons:type_name -> api.SeasonResult
+	40, // 27: api.RpcLeaderboardViewResponse.records:type_name -> api.LeaderboardViewRecord
+	40, // 28: api.RpcLeaderboardViewResponse.own_record:type_name -> api.LeaderboardViewRecord
+	34, // 29: api.RpcLeaderboardViewResponse.own_stats:type_name -> api.RpcGetPlayerStatsResponse
+	5,  // 30: api.Challenge.status:type_name -> api.ChallengeStatus
+	0,  // 31: api.Start.MarksEntry.value:type_name -> api.Mark
+	32, // [32:32] is the sub-list for method output_type
+	32, // [32:32] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_xoxoapi_proto_init() }
@@ -661,8 +4189,176 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Update); i {
+		file_xoxoapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Update); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Done); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rematch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpponentDisconnected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Move); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcFindMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcFindMatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListLiveMatchesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiveMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListLiveMatchesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcExportTrainingDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcExportTrainingDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListMatchHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchHistoryPlayer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchHistoryMove); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -673,8 +4369,8 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Done); i {
+		file_xoxoapi_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchHistoryGame); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -685,8 +4381,8 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Move); i {
+		file_xoxoapi_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListMatchHistoryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -697,8 +4393,8 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RpcFindMatchRequest); i {
+		file_xoxoapi_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetReplayRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -709,8 +4405,296 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RpcFindMatchResponse); i {
+		file_xoxoapi_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetReplayResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcWatchReplayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcWatchReplayResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcExportGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcExportGameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcImportGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetPlayerStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModeStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetPlayerStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListSeasonResultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeasonRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeasonResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListSeasonResultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcLeaderboardViewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaderboardViewRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcLeaderboardViewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcCreatePrivateMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcCreatePrivateMatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcJoinPrivateMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcJoinPrivateMatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Challenge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcChallengeFriendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcRespondChallengeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetActiveMatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -727,8 +4711,8 @@ func file_xoxoapi_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_xoxoapi_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      6,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+var _ MoveProvider = (*Batcher)(nil)
+var _ Scorer = (*Batcher)(nil)
+
+const (
+	// How long the first move asked for waits for others to share a request with.
+	DefaultBatchWindow = 10 * time.Millisecond
+	// Most boards sent in a single request.
+	DefaultMaxBatch = 128
+)
+
+// Batcher shares a model between every match. The moves asked for within a short window are gathered up and sent
+// in a single predict request, rather than one request per move, and each match gets back the predictions for its own
+// board.
+type Batcher struct {
+	predictor Predictor
+	window    time.Duration
+	maxBatch  int
+	requests  chan *batchRequest
+}
+
+type batchRequest struct {
+	ctx    context.Context
+	board  Board
+	result chan batchResult
+}
+
+type batchResult struct {
+	predictions []float64
+	err         error
+}
+
+// NewBatcher starts gathering requests for the given model, sending them at most window after the first one comes in,
+// or as soon as there are maxBatch of them.
+func NewBatcher(predictor Predictor, window time.Duration, maxBatch int) *Batcher {
+	b := &Batcher{
+		predictor: predictor,
+		window:    window,
+		maxBatch:  maxBatch,
+		requests:  make(chan *batchRequest, maxBatch),
+	}
+	go b.run()
+	return b
+}
+
+func (b *Batcher) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	return bestMove(ctx, b, g, mark)
+}
+
+// Scores are the log of the model's predictions of how good a move each position is.
+func (b *Batcher) Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	return predictScores(ctx, b, g, mark)
+}
+
+// Queue a single board for the next batch, and wait for its predictions.
+func (b *Batcher) predict(ctx context.Context, instances []Board) ([][]float64, error) {
+	predictions := make([][]float64, 0, len(instances))
+	for _, instance := range instances {
+		req := &batchRequest{
+			ctx:    ctx,
+			board:  instance,
+			result: make(chan batchResult, 1),
+		}
+
+		select {
+		case b.requests <- req:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		select {
+		case result := <-req.result:
+			if result.err != nil {
+				return nil, result.err
+			}
+			predictions = append(predictions, result.predictions)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return predictions, nil
+}
+
+func (b *Batcher) run() {
+	for {
+		batch := []*batchRequest{<-b.requests}
+
+		timer := time.NewTimer(b.window)
+	collect:
+		for len(batch) < b.maxBatch {
+			select {
+			case req := <-b.requests:
+				batch = append(batch, req)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		go b.send(batch)
+	}
+}
+
+// Ask the model about every board in the batch still waiting for an answer, one request per board size, and hand out
+// the predictions.
+func (b *Batcher) send(batch []*batchRequest) {
+	type size struct {
+		width, height int
+	}
+	groups := make(map[size][]*batchRequest)
+	for _, req := range batch {
+		if req.ctx.Err() != nil {
+			// Nobody's waiting for it anymore.
+			continue
+		}
+		s := size{width: len(req.board[0]), height: len(req.board)}
+		groups[s] = append(groups[s], req)
+	}
+
+	for _, group := range groups {
+		// Give the request as long as the most patient of its callers.
+		var deadline time.Time
+		instances := make([]Board, 0, len(group))
+		for _, req := range group {
+			d, ok := req.ctx.Deadline()
+			if !ok {
+				d = time.Now().Add(time.Minute)
+			}
+			if d.After(deadline) {
+				deadline = d
+			}
+			instances = append(instances, req.board)
+		}
+
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		predictions, err := b.predictor.predict(ctx, instances)
+		cancel()
+
+		for i, req := range group {
+			if err != nil {
+				req.result <- batchResult{err: err}
+			} else {
+				req.result <- batchResult{predictions: predictions[i]}
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+// A model that records the batches it's sent, and predicts for each board the cells' contents, so every caller can
+// tell its own predictions apart.
+type recordingModel struct {
+	mu      sync.Mutex
+	batches [][]Board
+	ctxs    []context.Context
+	// If set, predict waits for it to close before answering.
+	release chan struct{}
+	started chan int
+}
+
+func (m *recordingModel) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	return bestMove(ctx, m, g, mark)
+}
+
+func (m *recordingModel) Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	return predictScores(ctx, m, g, mark)
+}
+
+func (m *recordingModel) predict(ctx context.Context, instances []Board) ([][]float64, error) {
+	m.mu.Lock()
+	m.batches = append(m.batches, instances)
+	m.ctxs = append(m.ctxs, ctx)
+	m.mu.Unlock()
+
+	if m.release != nil {
+		m.started <- len(instances)
+		<-m.release
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	predictions := make([][]float64, len(instances))
+	for i, b := range instances {
+		predictions[i] = cellValues(b)
+	}
+	return predictions, nil
+}
+
+func (m *recordingModel) sentBatches() [][]Board {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.batches)
+}
+
+// What the recording model predicts for a board.
+func cellValues(b Board) []float64 {
+	var values []float64
+	for _, r := range b {
+		for _, c := range r {
+			values = append(values, float64(c[0]+2*c[1]))
+		}
+	}
+	return values
+}
+
+// A w x h board with its cells 0 if empty, 1 for the player to move and 2 for their opponent.
+func newBoard(w, h int, cells []int) Board {
+	b := make(Board, h)
+	for y := range b {
+		b[y] = make(Row, w)
+		for x := range b[y] {
+			switch cells[y*w+x] {
+			case 1:
+				b[y][x] = Cell{1, 0}
+			case 2:
+				b[y][x] = Cell{0, 1}
+			}
+		}
+	}
+	return b
+}
+
+// An empty w x h board, but for the player's mark at pos, which no two callers in a test share.
+func markedBoard(w, h, pos int) Board {
+	cells := make([]int, w*h)
+	cells[pos] = 1
+	return newBoard(w, h, cells)
+}
+
+func TestBatcherGroupsBySize(t *testing.T) {
+	model := &recordingModel{}
+	b := NewBatcher(model, 100*time.Millisecond, DefaultMaxBatch)
+
+	var boards []Board
+	for pos := 0; pos < 9; pos++ {
+		boards = append(boards, markedBoard(3, 3, pos))
+	}
+	for pos := 0; pos < 5; pos++ {
+		boards = append(boards, markedBoard(4, 4, pos), markedBoard(15, 15, pos*20))
+	}
+
+	var wg sync.WaitGroup
+	for _, instance := range boards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			predictions, err := b.predict(context.Background(), []Board{instance})
+			if err != nil {
+				t.Errorf("predict() = %v", err)
+				return
+			}
+			if !slices.Equal(predictions[0], cellValues(instance)) {
+				t.Errorf("got another caller's predictions")
+			}
+		}()
+	}
+	wg.Wait()
+
+	batches := model.sentBatches()
+	sizes := make(map[[2]int]int)
+	sent := 0
+	for _, batch := range batches {
+		w, h := len(batch[0][0]), len(batch[0])
+		for _, instance := range batch {
+			if len(instance[0]) != w || len(instance) != h {
+				t.Fatalf("%dx%d and %dx%d boards sent in one batch", w, h, len(instance[0]), len(instance))
+			}
+		}
+		sizes[[2]int{w, h}]++
+		sent += len(batch)
+	}
+	if sent != len(boards) {
+		t.Errorf("sent %d boards, want %d", sent, len(boards))
+	}
+	// All asked for within the window, so one request per size.
+	if want := map[[2]int]int{{3, 3}: 1, {4, 4}: 1, {15, 15}: 1}; !maps.Equal(sizes, want) {
+		t.Errorf("got batches by size %v, want %v", sizes, want)
+	}
+}
+
+func TestBatcherMaxBatch(t *testing.T) {
+	model := &recordingModel{}
+	b := NewBatcher(model, 100*time.Millisecond, 2)
+
+	var wg sync.WaitGroup
+	for pos := 0; pos < 5; pos++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instance := markedBoard(3, 3, pos)
+			predictions, err := b.predict(context.Background(), []Board{instance})
+			if err != nil || !slices.Equal(predictions[0], cellValues(instance)) {
+				t.Errorf("predict() = %v, %v, want %v", predictions, err, cellValues(instance))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, batch := range model.sentBatches() {
+		if len(batch) > 2 {
+			t.Errorf("sent a batch of %d, want at most 2", len(batch))
+		}
+	}
+}
+
+func TestBatcherDeadline(t *testing.T) {
+	model := &recordingModel{}
+	b := NewBatcher(model, 50*time.Millisecond, DefaultMaxBatch)
+
+	soon, later := time.Now().Add(time.Second), time.Now().Add(5*time.Second)
+	var wg sync.WaitGroup
+	for i, deadline := range []time.Time{soon, later} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithDeadline(context.Background(), deadline)
+			defer cancel()
+			if _, err := b.predict(ctx, []Board{markedBoard(3, 3, i)}); err != nil {
+				t.Errorf("predict() = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	model.mu.Lock()
+	defer model.mu.Unlock()
+	if len(model.ctxs) != 1 {
+		t.Fatalf("sent %d batches, want 1", len(model.ctxs))
+	}
+	// The request lasts as long as the most patient caller.
+	if deadline, ok := model.ctxs[0].Deadline(); !ok || !deadline.Equal(later) {
+		t.Errorf("request deadline %v, want %v", deadline, later)
+	}
+}
+
+func TestBatcherCancelledCaller(t *testing.T) {
+	model := &recordingModel{release: make(chan struct{}), started: make(chan int, 1)}
+	b := NewBatcher(model, 50*time.Millisecond, DefaultMaxBatch)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	type result struct {
+		pos int
+		err error
+	}
+	results := make(chan result, 3)
+	for pos, ctx := range []context.Context{cancelled, context.Background(), context.Background()} {
+		go func() {
+			instance := markedBoard(3, 3, pos)
+			predictions, err := b.predict(ctx, []Board{instance})
+			if err == nil && !slices.Equal(predictions[0], cellValues(instance)) {
+				err = errors.New("got another caller's predictions")
+			}
+			results <- result{pos, err}
+		}()
+	}
+
+	// Give up on the first caller once the batch is on its way, and let the model answer after it has.
+	if n := <-model.started; n != 3 {
+		t.Fatalf("sent a batch of %d, want 3", n)
+	}
+	cancel()
+	r := <-results
+	if r.pos != 0 || !errors.Is(r.err, context.Canceled) {
+		t.Fatalf("caller %d got %v first, want caller 0 cancelled", r.pos, r.err)
+	}
+	close(model.release)
+
+	for range 2 {
+		if r := <-results; r.err != nil {
+			t.Errorf("caller %d got %v, want its predictions", r.pos, r.err)
+		}
+	}
+}
+
+func TestBatcherSkipsCancelledCaller(t *testing.T) {
+	model := &recordingModel{}
+	b := NewBatcher(model, 100*time.Millisecond, DefaultMaxBatch)
+
+	// Gone before the batch is sent, so the model isn't asked about its board.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := b.predict(ctx, []Board{markedBoard(3, 3, 0)}); !errors.Is(err, context.Canceled) {
+		t.Errorf("predict() = %v, want %v", err, context.Canceled)
+	}
+
+	instance := markedBoard(3, 3, 1)
+	predictions, err := b.predict(context.Background(), []Board{instance})
+	if err != nil || !slices.Equal(predictions[0], cellValues(instance)) {
+		t.Fatalf("predict() = %v, %v, want %v", predictions, err, cellValues(instance))
+	}
+	for _, batch := range model.sentBatches() {
+		for _, sent := range batch {
+			if slices.Equal(cellValues(sent), cellValues(markedBoard(3, 3, 0))) {
+				t.Errorf("asked the model about a board nobody's waiting for")
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package bot chooses moves for the AI opponent. Each MoveProvider is a different way of picking them, from the
+// model served by TF Serving to the in-process solver or a fixed script, so a match can be played against any of them.
+package bot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+// Names the providers are chosen by, in the AI_ENGINE environment variable and the ai_provider match param.
+const (
+	// TF Serving's REST API.
+	ProviderTF = "tf"
+	// TF Serving's gRPC API.
+	ProviderTFGRPC = "tf_grpc"
+	// The in-process solver.
+	ProviderSolver = "solver"
+	// A scripted bot, for tests.
+	ProviderScripted = "scripted"
+)
+
+var (
+	ErrNoLegalMove      = errors.New("no legal move")
+	ErrUnexpectedOutput = errors.New("unexpected model output")
+)
+
+// MoveProvider picks the position to play for the given mark, which must be the one whose turn it is.
+type MoveProvider interface {
+	Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error)
+}
+
+// Scorer is implemented by providers that can rate every legal move rather than just pick one, so weaker AI levels
+// can choose among them. Higher is better, on a log scale.
+type Scorer interface {
+	Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error)
+}
+
+// Pick the best scored move, using the scores of any provider that can rate moves.
+func bestMove(ctx context.Context, scorer Scorer, g *game.Game, mark api.Mark) (int32, error) {
+	moves := g.LegalMoves()
+	if len(moves) == 0 {
+		return -1, ErrNoLegalMove
+	}
+
+	scores, err := scorer.Scores(ctx, g, mark)
+	if err != nil {
+		return -1, err
+	}
+
+	bestMove := moves[0]
+	for _, pos := range moves {
+		if scores[pos] > scores[bestMove] {
+			bestMove = pos
+		}
+	}
+	return bestMove, nil
+}
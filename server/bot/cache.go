@@ -0,0 +1,127 @@
+package bot
+
+import (
+	"context"
+	"sync"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+var _ MoveProvider = (*Cache)(nil)
+var _ Scorer = (*Cache)(nil)
+var _ Predictor = (*Cache)(nil)
+
+const (
+	// Most boards remembered, well beyond every reachable classic tic-tac-toe position.
+	DefaultCacheSize = 100000
+
+	cacheMetric = "ai_prediction_cache"
+)
+
+// Metrics counts cache hits and misses, as the Nakama runtime does.
+type Metrics interface {
+	MetricsCounterAdd(name string, tags map[string]string, delta int64)
+}
+
+// Cache remembers a model's predictions. Boards are keyed by their canonical form, so all the rotations and
+// reflections of a position share one entry, and the predictions are turned back to match the board asked about. This
+// relies on the model playing every symmetric form of a position the same way, as a well trained one does.
+type Cache struct {
+	sync.RWMutex
+	predictor Predictor
+	metrics   Metrics
+	size      int
+	// Predictions for the canonical form of each board.
+	entries map[string][]float64
+}
+
+// NewCache remembers up to size boards' predictions from the given model, reporting hits and misses to metrics if
+// it's not nil.
+func NewCache(predictor Predictor, metrics Metrics, size int) *Cache {
+	return &Cache{
+		predictor: predictor,
+		metrics:   metrics,
+		size:      size,
+		entries:   make(map[string][]float64),
+	}
+}
+
+func (c *Cache) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	return bestMove(ctx, c, g, mark)
+}
+
+// Scores are the log of the model's predictions of how good a move each position is.
+func (c *Cache) Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	return predictScores(ctx, c, g, mark)
+}
+
+func (c *Cache) predict(ctx context.Context, instances []Board) ([][]float64, error) {
+	predictions := make([][]float64, len(instances))
+	keys := make([]string, len(instances))
+	syms := make([]symmetry, len(instances))
+	var misses []int
+
+	c.RLock()
+	for i, instance := range instances {
+		keys[i], syms[i] = canonicalise(instance)
+		if canonical, ok := c.entries[keys[i]]; ok {
+			predictions[i] = fromCanonical(canonical, syms[i])
+		} else {
+			misses = append(misses, i)
+		}
+	}
+	c.RUnlock()
+
+	c.count("hit", len(instances)-len(misses))
+	c.count("miss", len(misses))
+	if len(misses) == 0 {
+		return predictions, nil
+	}
+
+	missed := make([]Board, 0, len(misses))
+	for _, i := range misses {
+		missed = append(missed, instances[i])
+	}
+	missedPredictions, err := c.predictor.predict(ctx, missed)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	for j, i := range misses {
+		predictions[i] = missedPredictions[j]
+		if len(c.entries) >= c.size {
+			// Start afresh rather than keep track of which boards were asked about least recently.
+			c.entries = make(map[string][]float64)
+		}
+		if len(predictions[i]) == len(syms[i]) {
+			c.entries[keys[i]] = toCanonical(predictions[i], syms[i])
+		}
+	}
+
+	return predictions, nil
+}
+
+func (c *Cache) count(result string, n int) {
+	if c.metrics != nil && n > 0 {
+		c.metrics.MetricsCounterAdd(cacheMetric, map[string]string{"result": result}, int64(n))
+	}
+}
+
+func toCanonical(predictions []float64, sym symmetry) []float64 {
+	canonical := make([]float64, len(predictions))
+	for i, p := range predictions {
+		canonical[sym[i]] = p
+	}
+	return canonical
+}
+
+func fromCanonical(canonical []float64, sym symmetry) []float64 {
+	predictions := make([]float64, len(canonical))
+	for i := range predictions {
+		predictions[i] = canonical[sym[i]]
+	}
+	return predictions
+}
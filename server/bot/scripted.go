@@ -0,0 +1,39 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+var _ MoveProvider = (*Scripted)(nil)
+
+// Scripted is a deterministic bot for tests. It plays the first position of its script that is still free, and once
+// the script runs out, the lowest free position on the board.
+type Scripted struct {
+	script []int32
+}
+
+func NewScripted(script []int32) *Scripted {
+	return &Scripted{script: script}
+}
+
+func (s *Scripted) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	if mark != g.Mark() {
+		return -1, game.ErrNotYourTurn
+	}
+
+	moves := g.LegalMoves()
+	if len(moves) == 0 {
+		return -1, ErrNoLegalMove
+	}
+
+	board := g.Board()
+	for _, pos := range s.script {
+		if pos >= 0 && int(pos) < len(board) && board[pos] == api.Mark_MARK_UNSPECIFIED {
+			return pos, nil
+		}
+	}
+	return moves[0], nil
+}
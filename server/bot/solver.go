@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+	"github.com/heroiclabs/nakama-project-template/solver"
+)
+
+var _ MoveProvider = (*Solver)(nil)
+var _ Scorer = (*Solver)(nil)
+
+// Solver plays perfectly, using the in-process solver. It only handles boards small enough to search exhaustively.
+type Solver struct {
+	solver *solver.Solver
+}
+
+func NewSolver(s *solver.Solver) *Solver {
+	return &Solver{solver: s}
+}
+
+func (s *Solver) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	if mark != g.Mark() {
+		return -1, game.ErrNotYourTurn
+	}
+	return s.solver.BestMove(ctx, g)
+}
+
+// Scores are the solver's evaluation of each move: positive for a win, the more so the sooner, negative for a loss and
+// zero for a draw.
+func (s *Solver) Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	if mark != g.Mark() {
+		return nil, game.ErrNotYourTurn
+	}
+
+	evaluation, err := s.solver.Evaluate(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[int32]float64, len(evaluation))
+	for pos, score := range evaluation {
+		scores[pos] = float64(score)
+	}
+	return scores, nil
+}
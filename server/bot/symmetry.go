@@ -0,0 +1,78 @@
+package bot
+
+import "sync"
+
+// A symmetry of the board, as the position each cell moves to.
+type symmetry []int
+
+// Rotations and reflections, as functions of a cell's coordinates on a w x h board. The last four swap the axes, so
+// only apply to square boards.
+var transforms = []func(x, y, w, h int) (int, int){
+	func(x, y, w, h int) (int, int) { return x, y },
+	func(x, y, w, h int) (int, int) { return w - 1 - x, y },
+	func(x, y, w, h int) (int, int) { return x, h - 1 - y },
+	func(x, y, w, h int) (int, int) { return w - 1 - x, h - 1 - y },
+	func(x, y, w, h int) (int, int) { return y, x },
+	func(x, y, w, h int) (int, int) { return h - 1 - y, x },
+	func(x, y, w, h int) (int, int) { return y, w - 1 - x },
+	func(x, y, w, h int) (int, int) { return h - 1 - y, w - 1 - x },
+}
+
+var symmetriesBySize sync.Map
+
+// Every symmetry of a w x h board: all 8 for a square one, otherwise the 4 that keep its shape.
+func symmetries(w, h int) []symmetry {
+	type size struct {
+		w, h int
+	}
+	if s, ok := symmetriesBySize.Load(size{w, h}); ok {
+		return s.([]symmetry)
+	}
+
+	n := 4
+	if w == h {
+		n = 8
+	}
+	syms := make([]symmetry, n)
+	for i := range syms {
+		syms[i] = make(symmetry, w*h)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				tx, ty := transforms[i](x, y, w, h)
+				syms[i][y*w+x] = ty*w + tx
+			}
+		}
+	}
+
+	symmetriesBySize.Store(size{w, h}, syms)
+	return syms
+}
+
+// Find the symmetric form of the board that sorts first, which every one of its forms shares. Returns its key and
+// the symmetry that gets there.
+func canonicalise(b Board) (string, symmetry) {
+	h, w := len(b), len(b[0])
+
+	cells := make([]byte, 0, w*h)
+	for _, r := range b {
+		for _, c := range r {
+			// Empty, own or opponent's mark.
+			cells = append(cells, byte(c[0]+2*c[1]))
+		}
+	}
+
+	var bestKey []byte
+	var bestSym symmetry
+	key := make([]byte, len(cells)+2)
+	for _, sym := range symmetries(w, h) {
+		key[0], key[1] = byte(w), byte(h)
+		for i, c := range cells {
+			key[2+sym[i]] = c
+		}
+		if bestKey == nil || string(key) < string(bestKey) {
+			bestKey = append(bestKey[:0], key...)
+			bestSym = sym
+		}
+	}
+	return string(bestKey), bestSym
+}
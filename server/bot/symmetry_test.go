@@ -0,0 +1,246 @@
+package bot
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+// Boards that no rotation or reflection leaves as they are, with their cells 0 if empty, 1 for the player to move and
+// 2 for their opponent.
+var asymmetric = []struct {
+	name  string
+	w, h  int
+	cells []int
+}{
+	// X O .
+	// . X .
+	// . . .
+	{"3x3", 3, 3, []int{1, 2, 0, 0, 1, 0, 0, 0, 0}},
+	{"4x4", 4, 4, []int{1, 2, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 2}},
+	{"4x3", 4, 3, []int{1, 2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 2}},
+}
+
+// Move every cell of the board to where the symmetry takes it.
+func transform(b Board, sym symmetry) Board {
+	h, w := len(b), len(b[0])
+	t := newBoard(w, h, make([]int, w*h))
+	for i, to := range sym {
+		t[to/w][to%w] = b[i/w][i%w]
+	}
+	return t
+}
+
+func TestSymmetries(t *testing.T) {
+	tests := []struct {
+		w, h, n int
+	}{
+		{3, 3, 8},
+		{4, 4, 8},
+		{15, 15, 8},
+		{4, 3, 4},
+		{3, 5, 4},
+	}
+	for _, tt := range tests {
+		w, h := tt.w, tt.h
+		syms := symmetries(w, h)
+		if len(syms) != tt.n {
+			t.Errorf("%dx%d: got %d symmetries, want %d", w, h, len(syms), tt.n)
+			continue
+		}
+
+		for i, sym := range syms {
+			// A permutation of the cells, which keeps neighbours next to each other.
+			if sorted := slices.Sorted(slices.Values(sym)); !slices.Equal(sorted, identity(w*h)) {
+				t.Fatalf("%dx%d: symmetry %d isn't a permutation: %v", w, h, i, sym)
+			}
+			for pos := range sym {
+				x, y := pos%w, pos/w
+				if x+1 < w && distance(sym[pos], sym[pos+1], w) != 1 {
+					t.Errorf("%dx%d: symmetry %d separates %d and %d", w, h, i, pos, pos+1)
+				}
+				if y+1 < h && distance(sym[pos], sym[pos+w], w) != 1 {
+					t.Errorf("%dx%d: symmetry %d separates %d and %d", w, h, i, pos, pos+w)
+				}
+			}
+
+			// Undone by one of the others, or itself.
+			inverse := make(symmetry, len(sym))
+			for from, to := range sym {
+				inverse[to] = from
+			}
+			if !slices.ContainsFunc(syms, func(s symmetry) bool { return slices.Equal(s, inverse) }) {
+				t.Errorf("%dx%d: symmetry %d has no inverse among the others", w, h, i)
+			}
+			for j := range syms[:i] {
+				if slices.Equal(syms[j], sym) {
+					t.Errorf("%dx%d: symmetries %d and %d are the same", w, h, j, i)
+				}
+			}
+		}
+		if !slices.Equal(syms[0], symmetry(identity(w*h))) {
+			t.Errorf("%dx%d: first symmetry isn't the identity", w, h)
+		}
+	}
+}
+
+func identity(n int) []int {
+	id := make([]int, n)
+	for i := range id {
+		id[i] = i
+	}
+	return id
+}
+
+// Steps between two cells, across and down.
+func distance(a, b, w int) int {
+	dx, dy := a%w-b%w, a/w-b/w
+	return max(dx, -dx) + max(dy, -dy)
+}
+
+func TestCanonicalise(t *testing.T) {
+	for _, tt := range asymmetric {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBoard(tt.w, tt.h, tt.cells)
+			key, _ := canonicalise(b)
+
+			var forms []Board
+			for i, sym := range symmetries(tt.w, tt.h) {
+				form := transform(b, sym)
+				if slices.ContainsFunc(forms, func(f Board) bool { return reflect.DeepEqual(f, form) }) {
+					t.Fatalf("symmetry %d gives a form seen already, the board isn't asymmetric", i)
+				}
+				forms = append(forms, form)
+
+				// Every form shares the key, and the symmetry returned takes it to the canonical form.
+				formKey, formSym := canonicalise(form)
+				if formKey != key {
+					t.Errorf("symmetry %d: key %q, want %q", i, formKey, key)
+				}
+				canonical, _ := canonicalise(transform(form, formSym))
+				if canonical != key {
+					t.Errorf("symmetry %d: canonical form has key %q, want %q", i, canonical, key)
+				}
+				if got := key[2:]; got != string(cellBytes(transform(form, formSym))) {
+					t.Errorf("symmetry %d: symmetry returned doesn't give the canonical form", i)
+				}
+			}
+		})
+	}
+
+	// Boards of different shapes, with the same cells in order, don't share keys.
+	wide, _ := canonicalise(newBoard(4, 3, make([]int, 12)))
+	tall, _ := canonicalise(newBoard(3, 4, make([]int, 12)))
+	if wide == tall {
+		t.Errorf("4x3 and 3x4 boards share the key %q", wide)
+	}
+}
+
+func cellBytes(b Board) []byte {
+	var cells []byte
+	for _, r := range b {
+		for _, c := range r {
+			cells = append(cells, byte(c[0]+2*c[1]))
+		}
+	}
+	return cells
+}
+
+func TestCanonicalRoundTrip(t *testing.T) {
+	for _, tt := range asymmetric {
+		predictions := make([]float64, tt.w*tt.h)
+		for i := range predictions {
+			predictions[i] = float64(i + 1)
+		}
+		for i, sym := range symmetries(tt.w, tt.h) {
+			canonical := toCanonical(predictions, sym)
+			for pos, p := range predictions {
+				if canonical[sym[pos]] != p {
+					t.Errorf("%s, symmetry %d: prediction for %d moved to the wrong cell", tt.name, i, pos)
+				}
+			}
+			if got := fromCanonical(canonical, sym); !slices.Equal(got, predictions) {
+				t.Errorf("%s, symmetry %d: fromCanonical(toCanonical()) = %v, want %v", tt.name, i, got, predictions)
+			}
+		}
+	}
+}
+
+// A model that only knows one board, in all its forms, and plays each form the same way.
+type symmetricModel struct {
+	t           *testing.T
+	board       Board
+	predictions []float64
+	calls       int
+}
+
+func (m *symmetricModel) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	return bestMove(ctx, m, g, mark)
+}
+
+func (m *symmetricModel) Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	return predictScores(ctx, m, g, mark)
+}
+
+func (m *symmetricModel) predict(ctx context.Context, instances []Board) ([][]float64, error) {
+	m.calls++
+	predictions := make([][]float64, len(instances))
+	for i, instance := range instances {
+		predictions[i] = m.predictForm(instance)
+		if predictions[i] == nil {
+			m.t.Fatalf("asked about a board it doesn't know: %v", instance)
+		}
+	}
+	return predictions, nil
+}
+
+// The predictions for a form of the board, moved to the cells the form moved them to.
+func (m *symmetricModel) predictForm(form Board) []float64 {
+	h, w := len(m.board), len(m.board[0])
+	for _, sym := range symmetries(w, h) {
+		if reflect.DeepEqual(transform(m.board, sym), form) {
+			predictions := make([]float64, len(sym))
+			for from, to := range sym {
+				predictions[to] = m.predictions[from]
+			}
+			return predictions
+		}
+	}
+	return nil
+}
+
+func TestCacheSymmetry(t *testing.T) {
+	for _, tt := range asymmetric {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &symmetricModel{t: t, board: newBoard(tt.w, tt.h, tt.cells)}
+			for i := range tt.cells {
+				model.predictions = append(model.predictions, float64(i+1)/10)
+			}
+			syms := symmetries(tt.w, tt.h)
+
+			// Whichever form is asked about first, the others come from the cache, turned to match.
+			for first := range syms {
+				cache := NewCache(model, nil, DefaultCacheSize)
+				model.calls = 0
+				for k := range syms {
+					i := (first + k) % len(syms)
+					form := transform(model.board, syms[i])
+					predictions, err := cache.predict(context.Background(), []Board{form})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if want := model.predictForm(form); !slices.Equal(predictions[0], want) {
+						t.Errorf("first %d, symmetry %d: predict() = %v, want %v", first, i, predictions[0], want)
+					}
+				}
+				if model.calls != 1 {
+					t.Errorf("first %d: model asked %d times, want once", first, model.calls)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+var _ MoveProvider = (*TF)(nil)
+var _ Scorer = (*TF)(nil)
+var _ Predictor = (*TF)(nil)
+
+// Cell is a board position as the model sees it: {1, 0} for the mark of the player to move, {0, 1} for their
+// opponent's, and {0, 0} if empty.
+type Cell [2]int
+
+// Row is a row of cells, left to right.
+type Row []Cell
+
+// Board is the model's input format for a board, one Row per board row, top to bottom.
+type Board []Row
+
+type tfRequest struct {
+	Instances []Board `json:"instances"`
+}
+
+type tfResponse struct {
+	Predictions [][]float64 `json:"predictions"`
+}
+
+// Predictor is a move provider backed by a model, which can be asked about many boards in a single request and
+// returns one prediction per board position for each of them. Predictors can be wrapped in one another, to batch
+// requests or cache the predictions.
+type Predictor interface {
+	MoveProvider
+	predict(ctx context.Context, instances []Board) ([][]float64, error)
+}
+
+// TF asks the model served by TF Serving for its moves, over the REST API.
+type TF struct {
+	address string
+	client  *http.Client
+}
+
+// NewTF sets up a provider posting to the given TF Serving predict URL, such as
+// http://tf:8501/v1/models/ttt:predict.
+func NewTF(address string) *TF {
+	return &TF{
+		address: address,
+		client:  &http.Client{},
+	}
+}
+
+func (t *TF) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	return bestMove(ctx, t, g, mark)
+}
+
+// Scores are the log of the model's predictions of how good a move each position is.
+func (t *TF) Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	return predictScores(ctx, t, g, mark)
+}
+
+func (t *TF) predict(ctx context.Context, instances []Board) ([][]float64, error) {
+	// Send the vectors to TF
+	req := tfRequest{Instances: instances}
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TF request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.address, bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to make TF request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make TF request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make TF request: %w", err)
+	}
+
+	// Convert response into message
+	predictions := tfResponse{}
+	if err := json.Unmarshal(respBody, &predictions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TF response: %w", err)
+	}
+
+	if len(predictions.Predictions) != len(instances) {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedOutput, respBody)
+	}
+
+	return predictions.Predictions, nil
+}
+
+// Ask a model about a single board, and score the legal moves by its predictions.
+func predictScores(ctx context.Context, p Predictor, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	if mark != g.Mark() {
+		return nil, game.ErrNotYourTurn
+	}
+
+	predictions, err := p.predict(ctx, []Board{EncodeBoard(g, mark)})
+	if err != nil {
+		return nil, err
+	}
+	return logScores(g, predictions[0])
+}
+
+// EncodeBoard converts the board into the model's input format, seen from the given mark's side.
+func EncodeBoard(g *game.Game, mark api.Mark) Board {
+	config := g.Config()
+	b := make(Board, config.Height)
+	for rowIdx := range b {
+		b[rowIdx] = make(Row, config.Width)
+	}
+
+	for i, m := range g.Board() {
+		b[i/config.Width][i%config.Width] = encodeCell(m, mark)
+	}
+	return b
+}
+
+func encodeCell(m api.Mark, mark api.Mark) Cell {
+	switch m {
+	case mark:
+		return Cell{1, 0}
+	case api.Mark_MARK_UNSPECIFIED:
+		return Cell{0, 0}
+	default:
+		return Cell{0, 1}
+	}
+}
+
+// Turn the model's predictions, one per board position, into log scores for the legal moves.
+func logScores(g *game.Game, predictions []float64) (map[int32]float64, error) {
+	config := g.Config()
+	if len(predictions) != config.Width*config.Height {
+		return nil, fmt.Errorf("%w: %d predictions", ErrUnexpectedOutput, len(predictions))
+	}
+
+	moves := g.LegalMoves()
+	scores := make(map[int32]float64, len(moves))
+	for _, pos := range moves {
+		scores[pos] = math.Log(math.Max(predictions[pos], 1e-9))
+	}
+	return scores, nil
+}
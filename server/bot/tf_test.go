@@ -0,0 +1,178 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+// Play the positions in turn, X first, failing the test on any rejected move.
+func play(t *testing.T, g *game.Game, positions ...int32) {
+	t.Helper()
+	for _, pos := range positions {
+		if err := g.ApplyMove(g.Mark(), pos); err != nil {
+			t.Fatalf("move %d: %v", pos, err)
+		}
+	}
+}
+
+// Serve TF Serving's REST predict API, answering with the given body and recording the instances asked about.
+func tfServer(t *testing.T, body string) (*httptest.Server, *[]Board) {
+	t.Helper()
+	var instances []Board
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/models/ttt:predict" {
+			t.Errorf("got %s %s, want POST /v1/models/ttt:predict", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("got Content-Type %q, want application/json", ct)
+		}
+		req := tfRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		instances = req.Instances
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &instances
+}
+
+func TestEncodeBoard(t *testing.T) {
+	g := game.New(game.Config{Width: 4, Height: 3, WinLength: 3})
+	// X . . .
+	// . O . .
+	// . . . X
+	play(t, g, 0, 5, 11)
+
+	x := Board{
+		{{1, 0}, {0, 0}, {0, 0}, {0, 0}},
+		{{0, 0}, {0, 1}, {0, 0}, {0, 0}},
+		{{0, 0}, {0, 0}, {0, 0}, {1, 0}},
+	}
+	if got := EncodeBoard(g, api.Mark_MARK_X); !reflect.DeepEqual(got, x) {
+		t.Errorf("EncodeBoard(X) = %v, want %v", got, x)
+	}
+
+	o := Board{
+		{{0, 1}, {0, 0}, {0, 0}, {0, 0}},
+		{{0, 0}, {1, 0}, {0, 0}, {0, 0}},
+		{{0, 0}, {0, 0}, {0, 0}, {0, 1}},
+	}
+	if got := EncodeBoard(g, api.Mark_MARK_O); !reflect.DeepEqual(got, o) {
+		t.Errorf("EncodeBoard(O) = %v, want %v", got, o)
+	}
+}
+
+func TestTFRequest(t *testing.T) {
+	server, instances := tfServer(t, `{"predictions": [[0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1]]}`)
+	g := game.New(game.DefaultConfig())
+	play(t, g, 4)
+
+	if _, err := NewTF(server.URL+"/v1/models/ttt:predict").Move(context.Background(), g, api.Mark_MARK_O); err != nil {
+		t.Fatal(err)
+	}
+	// Sent from O's side, as the player to move.
+	want := []Board{{
+		{{0, 0}, {0, 0}, {0, 0}},
+		{{0, 0}, {0, 1}, {0, 0}},
+		{{0, 0}, {0, 0}, {0, 0}},
+	}}
+	if !reflect.DeepEqual(*instances, want) {
+		t.Errorf("sent instances %v, want %v", *instances, want)
+	}
+}
+
+func TestTFMove(t *testing.T) {
+	// The model likes the taken centre best, which has to be passed over for the best legal move.
+	server, _ := tfServer(t, `{"predictions": [[0.1, 0.2, 0.05, 0.3, 0.9, 0.6, 0.0, 0.4, 0.1]]}`)
+	tf := NewTF(server.URL + "/v1/models/ttt:predict")
+	g := game.New(game.DefaultConfig())
+	play(t, g, 4)
+
+	move, err := tf.Move(context.Background(), g, api.Mark_MARK_O)
+	if err != nil || move != 5 {
+		t.Errorf("Move() = %d, %v, want 5", move, err)
+	}
+
+	scores, err := tf.Scores(context.Background(), g, api.Mark_MARK_O)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != 8 {
+		t.Errorf("got %d scores, want one for each of the 8 legal moves", len(scores))
+	}
+	if _, ok := scores[4]; ok {
+		t.Errorf("scored the taken centre")
+	}
+	if got, want := scores[3], math.Log(0.3); got != want {
+		t.Errorf("score of 3 = %v, want %v", got, want)
+	}
+	// Predictions of zero are floored rather than scored as -Inf.
+	if got := scores[6]; math.IsInf(got, -1) || got >= scores[2] {
+		t.Errorf("score of 6 = %v, want finite and below %v", got, scores[2])
+	}
+}
+
+func TestTFRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+	}{
+		{"not JSON", `<html>Bad Gateway</html>`, nil},
+		{"error", `{"error": "Servable not found for request: Latest(ttt)"}`, ErrUnexpectedOutput},
+		{"too many instances", `{"predictions": [[0, 0, 0, 0, 0, 0, 0, 0, 1], [1, 0, 0, 0, 0, 0, 0, 0, 0]]}`, ErrUnexpectedOutput},
+		{"too few predictions", `{"predictions": [[0, 0, 0, 0, 0, 0, 0, 1]]}`, ErrUnexpectedOutput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := tfServer(t, tt.body)
+			move, err := NewTF(server.URL+"/v1/models/ttt:predict").Move(context.Background(), game.New(game.DefaultConfig()), api.Mark_MARK_X)
+			if err == nil {
+				t.Fatalf("Move() = %d, want an error", move)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("Move() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTFNotAsked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("asked the model out of turn")
+	}))
+	defer server.Close()
+	tf := NewTF(server.URL)
+
+	g := game.New(game.DefaultConfig())
+	if _, err := tf.Move(context.Background(), g, api.Mark_MARK_O); !errors.Is(err, game.ErrNotYourTurn) {
+		t.Errorf("Move() = %v, want %v", err, game.ErrNotYourTurn)
+	}
+
+	play(t, g, 0, 3, 1, 4, 2)
+	if _, err := tf.Move(context.Background(), g, api.Mark_MARK_O); !errors.Is(err, ErrNoLegalMove) {
+		t.Errorf("Move() = %v after the game is over, want %v", err, ErrNoLegalMove)
+	}
+}
+
+func TestTFCancelled(t *testing.T) {
+	server, _ := tfServer(t, `{"predictions": [[0, 0, 0, 0, 0, 0, 0, 0, 1]]}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewTF(server.URL+"/v1/models/ttt:predict").Move(ctx, game.New(game.DefaultConfig()), api.Mark_MARK_X)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Move() = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,254 @@
+package bot
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+var _ MoveProvider = (*TFGRPC)(nil)
+var _ Scorer = (*TFGRPC)(nil)
+var _ Predictor = (*TFGRPC)(nil)
+
+const (
+	tfPredictMethod = "/tensorflow.serving.PredictionService/Predict"
+	tfDefaultModel  = "ttt"
+	// Signature and input name TF Serving gives the exported Keras model.
+	tfDefaultSignature = "serving_default"
+	tfDefaultInput     = "conv2d_1_input"
+
+	// TensorProto DataType for 32-bit floats.
+	tfFloat = 1
+)
+
+// TFGRPC asks the model served by TF Serving for its moves, over the gRPC PredictionService. Requests and responses
+// are encoded by hand rather than with the TensorFlow Serving stubs, which only a handful of fields are needed from.
+type TFGRPC struct {
+	conn      *grpc.ClientConn
+	model     string
+	signature string
+	input     string
+}
+
+// NewTFGRPC sets up a provider calling TF Serving at the given address, such as tf:8500. The connection is made
+// lazily, on the first move asked for.
+func NewTFGRPC(address string) (*TFGRPC, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up TF gRPC client: %w", err)
+	}
+
+	return &TFGRPC{
+		conn:      conn,
+		model:     tfDefaultModel,
+		signature: tfDefaultSignature,
+		input:     tfDefaultInput,
+	}, nil
+}
+
+func (t *TFGRPC) Move(ctx context.Context, g *game.Game, mark api.Mark) (int32, error) {
+	return bestMove(ctx, t, g, mark)
+}
+
+// Scores are the log of the model's predictions of how good a move each position is.
+func (t *TFGRPC) Scores(ctx context.Context, g *game.Game, mark api.Mark) (map[int32]float64, error) {
+	return predictScores(ctx, t, g, mark)
+}
+
+// All instances must be boards of the same size, they're sent as a single tensor.
+func (t *TFGRPC) predict(ctx context.Context, instances []Board) ([][]float64, error) {
+	height, width := len(instances[0]), len(instances[0][0])
+	values := make([]float32, 0, len(instances)*height*width*2)
+	for _, b := range instances {
+		for _, r := range b {
+			for _, c := range r {
+				values = append(values, float32(c[0]), float32(c[1]))
+			}
+		}
+	}
+
+	req := t.encodeRequest([]int64{int64(len(instances)), int64(height), int64(width), 2}, values)
+	resp := rawMessage{}
+	if err := t.conn.Invoke(ctx, tfPredictMethod, rawMessage(req), &resp, grpc.ForceCodec(rawCodec{})); err != nil {
+		return nil, fmt.Errorf("failed to make TF request: %w", err)
+	}
+
+	outputs, err := decodeResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TF response: %w", err)
+	}
+	if len(outputs) != len(instances)*height*width {
+		return nil, fmt.Errorf("%w: %d predictions", ErrUnexpectedOutput, len(outputs))
+	}
+
+	// One row of predictions per instance.
+	predictions := make([][]float64, len(instances))
+	for i := range predictions {
+		predictions[i] = outputs[i*height*width : (i+1)*height*width]
+	}
+	return predictions, nil
+}
+
+// Encode a PredictRequest with a single float tensor input.
+func (t *TFGRPC) encodeRequest(shape []int64, values []float32) []byte {
+	// ModelSpec: name = 1, signature_name = 3.
+	var spec []byte
+	spec = protowire.AppendTag(spec, 1, protowire.BytesType)
+	spec = protowire.AppendString(spec, t.model)
+	spec = protowire.AppendTag(spec, 3, protowire.BytesType)
+	spec = protowire.AppendString(spec, t.signature)
+
+	// TensorShapeProto: repeated Dim dim = 2, each with size = 1.
+	var tensorShape []byte
+	for _, size := range shape {
+		var dim []byte
+		dim = protowire.AppendTag(dim, 1, protowire.VarintType)
+		dim = protowire.AppendVarint(dim, uint64(size))
+		tensorShape = protowire.AppendTag(tensorShape, 2, protowire.BytesType)
+		tensorShape = protowire.AppendBytes(tensorShape, dim)
+	}
+
+	// TensorProto: dtype = 1, tensor_shape = 2, packed float_val = 5.
+	var floats []byte
+	for _, v := range values {
+		floats = protowire.AppendFixed32(floats, math.Float32bits(v))
+	}
+	var tensor []byte
+	tensor = protowire.AppendTag(tensor, 1, protowire.VarintType)
+	tensor = protowire.AppendVarint(tensor, tfFloat)
+	tensor = protowire.AppendTag(tensor, 2, protowire.BytesType)
+	tensor = protowire.AppendBytes(tensor, tensorShape)
+	tensor = protowire.AppendTag(tensor, 5, protowire.BytesType)
+	tensor = protowire.AppendBytes(tensor, floats)
+
+	// Map entry of inputs: key = 1, value = 2.
+	var input []byte
+	input = protowire.AppendTag(input, 1, protowire.BytesType)
+	input = protowire.AppendString(input, t.input)
+	input = protowire.AppendTag(input, 2, protowire.BytesType)
+	input = protowire.AppendBytes(input, tensor)
+
+	// PredictRequest: model_spec = 1, inputs = 2.
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendBytes(req, spec)
+	req = protowire.AppendTag(req, 2, protowire.BytesType)
+	req = protowire.AppendBytes(req, input)
+	return req
+}
+
+// Decode the float values of the only output of a PredictResponse, whether sent as float_val or tensor_content.
+func decodeResponse(resp []byte) ([]float64, error) {
+	// PredictResponse: outputs = 1, a map entry with value = 2.
+	var tensor []byte
+	outputs := 0
+	err := eachField(resp, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != 1 || typ != protowire.BytesType {
+			return nil
+		}
+		outputs++
+		return eachField(value, func(num protowire.Number, typ protowire.Type, value []byte) error {
+			if num == 2 && typ == protowire.BytesType {
+				tensor = value
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if outputs != 1 {
+		return nil, fmt.Errorf("%w: %d outputs", ErrUnexpectedOutput, outputs)
+	}
+
+	// TensorProto: tensor_content = 4, float_val = 5, packed or not.
+	var predictions []float64
+	err = eachField(tensor, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch {
+		case num == 4 && typ == protowire.BytesType:
+			for len(value) >= 4 {
+				predictions = append(predictions, float64(math.Float32frombits(binary.LittleEndian.Uint32(value))))
+				value = value[4:]
+			}
+		case num == 5 && typ == protowire.BytesType:
+			for len(value) > 0 {
+				v, n := protowire.ConsumeFixed32(value)
+				if n < 0 {
+					return protowire.ParseError(n)
+				}
+				predictions = append(predictions, float64(math.Float32frombits(v)))
+				value = value[n:]
+			}
+		case num == 5 && typ == protowire.Fixed32Type:
+			v, _ := protowire.ConsumeFixed32(value)
+			predictions = append(predictions, float64(math.Float32frombits(v)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return predictions, nil
+}
+
+// Call fn for every field of an encoded message, with the field's raw value: the payload of length-delimited fields,
+// otherwise the encoded value itself.
+func eachField(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		m := protowire.ConsumeFieldValue(num, typ, b)
+		if m < 0 {
+			return protowire.ParseError(m)
+		}
+		value := b[:m]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		if err := fn(num, typ, value); err != nil {
+			return err
+		}
+		b = b[m:]
+	}
+	return nil
+}
+
+// rawMessage is an already encoded protobuf message.
+type rawMessage []byte
+
+// rawCodec passes rawMessage through to gRPC untouched.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(rawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return msg, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(*rawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*msg = append((*msg)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,208 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+func TestTFGRPCEncodeRequest(t *testing.T) {
+	tf := &TFGRPC{model: "ttt", signature: "serving_default", input: "in"}
+	got := tf.encodeRequest([]int64{1, 1, 1, 2}, []float32{1, 0})
+
+	want := []byte{
+		// model_spec
+		0x0a, 22,
+		0x0a, 3, 't', 't', 't',
+		0x1a, 15, 's', 'e', 'r', 'v', 'i', 'n', 'g', '_', 'd', 'e', 'f', 'a', 'u', 'l', 't',
+		// inputs
+		0x12, 36,
+		0x0a, 2, 'i', 'n',
+		0x12, 30,
+		// dtype
+		0x08, tfFloat,
+		// tensor_shape
+		0x12, 16,
+		0x12, 2, 0x08, 1,
+		0x12, 2, 0x08, 1,
+		0x12, 2, 0x08, 1,
+		0x12, 2, 0x08, 2,
+		// float_val, packed
+		0x2a, 8,
+		0x00, 0x00, 0x80, 0x3f,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("encodeRequest() =\n% x\nwant\n% x", got, want)
+	}
+}
+
+// A PredictResponse with a single output, named out, holding the given TensorProto.
+func predictResponse(tensor ...byte) []byte {
+	output := append([]byte{0x0a, 3, 'o', 'u', 't', 0x12, byte(len(tensor))}, tensor...)
+	return append([]byte{0x0a, byte(len(output))}, output...)
+}
+
+func TestTFGRPCDecodeResponse(t *testing.T) {
+	// 0.25 and 0.75 as little-endian float32.
+	quarter := []byte{0x00, 0x00, 0x80, 0x3e}
+	threeQuarters := []byte{0x00, 0x00, 0x40, 0x3f}
+	cat := func(parts ...[]byte) []byte {
+		var b []byte
+		for _, p := range parts {
+			b = append(b, p...)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name string
+		resp []byte
+		want []float64
+	}{
+		{"packed float_val", predictResponse(cat([]byte{0x08, tfFloat, 0x2a, 8}, quarter, threeQuarters)...), []float64{0.25, 0.75}},
+		{"unpacked float_val", predictResponse(cat([]byte{0x2d}, quarter, []byte{0x2d}, threeQuarters)...), []float64{0.25, 0.75}},
+		{"tensor_content", predictResponse(cat([]byte{0x22, 8}, quarter, threeQuarters)...), []float64{0.25, 0.75}},
+		{"shape and unknown fields", cat(
+			// model_spec, which the response echoes back.
+			[]byte{0x12, 5, 0x0a, 3, 't', 't', 't'},
+			predictResponse(cat(
+				[]byte{0x08, tfFloat, 0x12, 8, 0x12, 2, 0x08, 1, 0x12, 2, 0x08, 2},
+				[]byte{0x78, 7},
+				[]byte{0x2a, 8}, quarter, threeQuarters)...),
+		), []float64{0.25, 0.75}},
+		{"no values", predictResponse(0x08, tfFloat), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeResponse(tt.resp)
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeResponse(% x) = %v, %v, want %v", tt.resp, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTFGRPCDecodeResponseRejected(t *testing.T) {
+	output := predictResponse(0x2d, 0x00, 0x00, 0x80, 0x3e)
+	tests := []struct {
+		name string
+		resp []byte
+		err  error
+	}{
+		{"no outputs", nil, ErrUnexpectedOutput},
+		{"two outputs", append(append([]byte{}, output...), output...), ErrUnexpectedOutput},
+		{"truncated", output[:len(output)-2], nil},
+		{"truncated tensor", predictResponse(0x2a, 8, 0x00, 0x00, 0x80, 0x3e), nil},
+		{"bad packed float_val", predictResponse(0x2a, 3, 0x00, 0x00, 0x80), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeResponse(tt.resp)
+			if err == nil {
+				t.Fatalf("decodeResponse(% x) = %v, want an error", tt.resp, got)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("decodeResponse(% x) = %v, want %v", tt.resp, err, tt.err)
+			}
+		})
+	}
+}
+
+// Serve TF Serving's PredictionService, answering every request with the given response and recording the requests.
+func tfGRPCServer(t *testing.T, resp []byte) (*TFGRPC, *[][]byte) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests [][]byte
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		if method, _ := grpc.MethodFromServerStream(stream); method != tfPredictMethod {
+			t.Errorf("called %s, want %s", method, tfPredictMethod)
+		}
+		req := rawMessage{}
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		requests = append(requests, req)
+		return stream.SendMsg(rawMessage(resp))
+	}))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	tf, err := NewTFGRPC(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tf.conn.Close() })
+	return tf, &requests
+}
+
+// A response of float_val, as TF Serving sends it.
+func floatResponse(values ...float32) []byte {
+	tensor := []byte{0x08, tfFloat, 0x2a, byte(len(values) * 4)}
+	for _, v := range values {
+		bits := math.Float32bits(v)
+		tensor = append(tensor, byte(bits), byte(bits>>8), byte(bits>>16), byte(bits>>24))
+	}
+	return predictResponse(tensor...)
+}
+
+func TestTFGRPCPredict(t *testing.T) {
+	tf, requests := tfGRPCServer(t, floatResponse(
+		0.5, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0.25,
+	))
+	x := Board{{{1, 0}, {0, 0}, {0, 0}}, {{0, 0}, {0, 1}, {0, 0}}, {{0, 0}, {0, 0}, {0, 0}}}
+	o := Board{{{0, 1}, {0, 0}, {0, 0}}, {{0, 0}, {1, 0}, {0, 0}}, {{0, 0}, {0, 0}, {0, 0}}}
+
+	predictions, err := tf.predict(context.Background(), []Board{x, o})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]float64{
+		{0.5, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0.25},
+	}
+	if !reflect.DeepEqual(predictions, want) {
+		t.Errorf("predict() = %v, want %v", predictions, want)
+	}
+
+	// Both boards go as one tensor, cell by cell, each cell's two channels together.
+	values := []float32{
+		1, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 1, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if len(*requests) != 1 || !reflect.DeepEqual((*requests)[0], tf.encodeRequest([]int64{2, 3, 3, 2}, values)) {
+		t.Errorf("sent % x", *requests)
+	}
+}
+
+func TestTFGRPCMove(t *testing.T) {
+	// The model likes the taken corner best, which has to be passed over for the best legal move.
+	tf, _ := tfGRPCServer(t, floatResponse(0.9, 0.1, 0.2, 0.1, 0.7, 0.1, 0.1, 0.1, 0.1))
+	g := game.New(game.DefaultConfig())
+	play(t, g, 0)
+
+	move, err := tf.Move(context.Background(), g, api.Mark_MARK_O)
+	if err != nil || move != 4 {
+		t.Errorf("Move() = %d, %v, want 4", move, err)
+	}
+}
+
+func TestTFGRPCUnexpectedOutput(t *testing.T) {
+	tf, _ := tfGRPCServer(t, floatResponse(0.1, 0.2, 0.3))
+	if _, err := tf.Move(context.Background(), game.New(game.DefaultConfig()), api.Mark_MARK_X); !errors.Is(err, ErrUnexpectedOutput) {
+		t.Errorf("Move() = %v, want %v", err, ErrUnexpectedOutput)
+	}
+}
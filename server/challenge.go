@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Challenges between friends, by the ID of the match held for them, owned by the system user.
+	challengeCollection = "challenges"
+
+	// How long a challenged friend has to join, unless CHALLENGE_EXPIRY_SEC says otherwise.
+	defaultChallengeExpiry = 5 * time.Minute
+
+	// Sent to the match held for a challenge when the friend declines it.
+	challengeDeclinedSignal = "challenge_declined"
+
+	notificationCodeChallenge         = 103
+	notificationCodeChallengeResponse = 104
+)
+
+var errChallengeObjectNotFound = errors.New("challenge not in storage")
+
+type challenge struct {
+	MatchID            string              `json:"match_id"`
+	ChallengerID       string              `json:"challenger_id"`
+	ChallengerUsername string              `json:"challenger_username"`
+	OpponentID         string              `json:"opponent_id"`
+	Fast               bool                `json:"fast"`
+	Width              int                 `json:"width"`
+	Height             int                 `json:"height"`
+	WinLength          int                 `json:"win_length"`
+	SeriesLength       int                 `json:"series_length"`
+	Status             api.ChallengeStatus `json:"status"`
+	// When the friend has to join by, in Unix seconds.
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// Challenge a friend to a match held for the two of them. The friend gets a persistent notification carrying the
+// match ID and mode, and answers it with respond_challenge. The match closes if they haven't both joined by the time
+// the challenge expires.
+func rpcChallengeFriend(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, expiry time.Duration) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+		username, _ := ctx.Value(runtime.RUNTIME_CTX_USERNAME).(string)
+
+		request := &api.RpcChallengeFriendRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+		if request.UserId == "" || request.UserId == userID {
+			return "", errBadInput
+		}
+
+		config := boardConfig(request.Width, request.Height, request.WinLength)
+		if err := config.Validate(); err != nil {
+			logger.Debug("invalid board requested: %v", err)
+			return "", errBadInput
+		}
+
+		seriesLength := 1
+		if request.SeriesLength > 0 {
+			seriesLength = int(request.SeriesLength)
+		}
+		if !validSeriesLength(seriesLength) {
+			return "", errBadInput
+		}
+
+		friendIDs, err := listFriendIDs(ctx, nk, userID)
+		if err != nil {
+			logger.Error("error listing friends of %s: %v", userID, err)
+			return "", errInternalError
+		}
+		if !slices.Contains(friendIDs, request.UserId) {
+			return "", errNotFriends
+		}
+
+		matchID, err := nk.MatchCreate(ctx, moduleName, map[string]interface{}{
+			"fast": request.Fast, "width": config.Width, "height": config.Height, "win_length": config.WinLength,
+			"series_length": seriesLength, "user_ids": []string{userID, request.UserId},
+			"challenge_expiry_sec": int(expiry / time.Second)})
+		if err != nil {
+			logger.Error("error creating match: %v", err)
+			return "", errInternalError
+		}
+
+		c := &challenge{
+			MatchID:            matchID,
+			ChallengerID:       userID,
+			ChallengerUsername: username,
+			OpponentID:         request.UserId,
+			Fast:               request.Fast,
+			Width:              config.Width,
+			Height:             config.Height,
+			WinLength:          config.WinLength,
+			SeriesLength:       seriesLength,
+			Status:             api.ChallengeStatus_CHALLENGE_STATUS_PENDING,
+			ExpiresAt:          time.Now().Add(expiry).Unix(),
+		}
+		// A version of "*" only writes the object if it doesn't exist yet.
+		if err := writeChallenge(ctx, nk, c, "*"); err != nil {
+			// The match closes by itself once the challenge expires.
+			logger.Error("error writing challenge %s: %v", matchID, err)
+			return "", errInternalError
+		}
+
+		if err := nk.NotificationSend(ctx, request.UserId, "Challenge", c.content(), notificationCodeChallenge, userID, true); err != nil {
+			logger.Error("error sending challenge notification to %s: %v", request.UserId, err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(c.response())
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		logger.Info("new challenge match created %s for %s and %s", matchID, userID, request.UserId)
+		return string(response), nil
+	}
+}
+
+// Accept or decline a challenge, letting the challenger know. Declining closes the match held for it.
+func rpcRespondChallenge(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcRespondChallengeRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		c, version, err := readChallenge(ctx, nk, request.MatchId)
+		if err != nil {
+			if errors.Is(err, errChallengeObjectNotFound) {
+				return "", errChallengeNotFound
+			}
+			logger.Error("error reading challenge %s: %v", request.MatchId, err)
+			return "", errInternalError
+		}
+		// Only the challenged friend may answer, and nobody else learns the challenge exists.
+		if c.OpponentID != userID {
+			return "", errChallengeNotFound
+		}
+		if c.Status != api.ChallengeStatus_CHALLENGE_STATUS_PENDING || time.Now().Unix() >= c.ExpiresAt {
+			return "", errChallengeClosed
+		}
+
+		c.Status = api.ChallengeStatus_CHALLENGE_STATUS_DECLINED
+		if request.Accept {
+			c.Status = api.ChallengeStatus_CHALLENGE_STATUS_ACCEPTED
+		}
+		// The write fails if the challenge expired in the meantime.
+		if err := writeChallenge(ctx, nk, c, version); err != nil {
+			logger.Warn("error answering challenge %s, it may have expired: %v", c.MatchID, err)
+			return "", errChallengeClosed
+		}
+
+		if !request.Accept {
+			if _, err := nk.MatchSignal(ctx, c.MatchID, challengeDeclinedSignal); err != nil {
+				// It closes by itself once the challenge expires anyway.
+				logger.Warn("error signalling match %s: %v", c.MatchID, err)
+			}
+		}
+
+		if err := nk.NotificationSend(ctx, c.ChallengerID, "Challenge answered", c.content(), notificationCodeChallengeResponse, userID, true); err != nil {
+			logger.Error("error sending challenge response notification to %s: %v", c.ChallengerID, err)
+		}
+
+		response, err := marshaler.Marshal(c.response())
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Mark a challenge that's still waiting for an answer expired, and let the challenger know. Called by the match held
+// for it as it closes, failing to is logged.
+func expireChallenge(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, matchID string) {
+	c, version, err := readChallenge(ctx, nk, matchID)
+	if err != nil {
+		logger.Error("error reading challenge %s: %v", matchID, err)
+		return
+	}
+	if c.Status != api.ChallengeStatus_CHALLENGE_STATUS_PENDING {
+		// Accepted, but the players didn't both join in time. The challenger knows already.
+		return
+	}
+
+	c.Status = api.ChallengeStatus_CHALLENGE_STATUS_EXPIRED
+	if err := writeChallenge(ctx, nk, c, version); err != nil {
+		logger.Warn("error expiring challenge %s, it may have been answered: %v", matchID, err)
+		return
+	}
+
+	if err := nk.NotificationSend(ctx, c.ChallengerID, "Challenge expired", c.content(), notificationCodeChallengeResponse, c.OpponentID, true); err != nil {
+		logger.Error("error sending challenge expiry notification to %s: %v", c.ChallengerID, err)
+	}
+}
+
+func readChallenge(ctx context.Context, nk runtime.NakamaModule, matchID string) (*challenge, string, error) {
+	if matchID == "" {
+		return nil, "", errChallengeObjectNotFound
+	}
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: challengeCollection,
+		Key:        matchID,
+	}})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(objects) == 0 {
+		return nil, "", errChallengeObjectNotFound
+	}
+	c := &challenge{}
+	if err := json.Unmarshal([]byte(objects[0].Value), c); err != nil {
+		return nil, "", err
+	}
+	return c, objects[0].Version, nil
+}
+
+// Write a challenge, if its version still matches.
+func writeChallenge(ctx context.Context, nk runtime.NakamaModule, c *challenge, version string) error {
+	value, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      challengeCollection,
+		Key:             c.MatchID,
+		Value:           string(value),
+		Version:         version,
+		PermissionRead:  0,
+		PermissionWrite: 0,
+	}})
+	return err
+}
+
+// The challenge as notification content, which clients read as JSON.
+func (c *challenge) content() map[string]interface{} {
+	return map[string]interface{}{
+		"match_id":            c.MatchID,
+		"challenger_id":       c.ChallengerID,
+		"challenger_username": c.ChallengerUsername,
+		"opponent_id":         c.OpponentID,
+		"fast":                c.Fast,
+		"width":               c.Width,
+		"height":              c.Height,
+		"win_length":          c.WinLength,
+		"series_length":       c.SeriesLength,
+		"status":              int32(c.Status),
+		"expires_at":          c.ExpiresAt,
+	}
+}
+
+func (c *challenge) response() *api.Challenge {
+	return &api.Challenge{
+		MatchId:            c.MatchID,
+		ChallengerId:       c.ChallengerID,
+		ChallengerUsername: c.ChallengerUsername,
+		OpponentId:         c.OpponentID,
+		Fast:               c.Fast,
+		Width:              int32(c.Width),
+		Height:             int32(c.Height),
+		WinLength:          int32(c.WinLength),
+		SeriesLength:       int32(c.SeriesLength),
+		Status:             c.Status,
+		ExpiresAt:          c.ExpiresAt,
+	}
+}
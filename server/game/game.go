@@ -0,0 +1,304 @@
+// Package game implements the tic-tac-toe rules independently of the Nakama runtime, so they can be
+// driven by the match handler, the AI, or plain Go code. Boards of any size up to MaxSize are supported,
+// with a configurable number of marks in a row needed to win.
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+var (
+	ErrGameOver        = errors.New("game is over")
+	ErrNotYourTurn     = errors.New("not this player's turn")
+	ErrInvalidPosition = errors.New("position outside the board")
+	ErrPositionTaken   = errors.New("position already played")
+)
+
+const (
+	// Classic 3x3 tic-tac-toe.
+	DefaultWidth     = 3
+	DefaultHeight    = 3
+	DefaultWinLength = 3
+
+	// Largest board side supported, enough for 15x15 Gomoku and 19x19 variants.
+	MaxSize = 19
+)
+
+// Config describes the board dimensions and how many marks in a row are needed to win.
+type Config struct {
+	Width     int
+	Height    int
+	WinLength int
+}
+
+// DefaultConfig returns the classic 3x3, three-in-a-row setup.
+func DefaultConfig() Config {
+	return Config{
+		Width:     DefaultWidth,
+		Height:    DefaultHeight,
+		WinLength: DefaultWinLength,
+	}
+}
+
+// Validate checks the board fits within the supported limits and that a line of the required length fits on it.
+func (c Config) Validate() error {
+	if c.Width < DefaultWidth || c.Width > MaxSize || c.Height < DefaultHeight || c.Height > MaxSize {
+		return fmt.Errorf("board must be between %dx%d and %dx%d, got %dx%d",
+			DefaultWidth, DefaultHeight, MaxSize, MaxSize, c.Width, c.Height)
+	}
+	if c.WinLength < DefaultWinLength || (c.WinLength > c.Width && c.WinLength > c.Height) {
+		return fmt.Errorf("win length %d does not fit on a %dx%d board", c.WinLength, c.Width, c.Height)
+	}
+	return nil
+}
+
+// winningPositions lists every line of WinLength consecutive cells: rows, columns and both diagonals.
+func (c Config) winningPositions() [][]int32 {
+	directions := [][2]int{{1, 0}, {0, 1}, {1, 1}, {-1, 1}}
+
+	var lines [][]int32
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			for _, d := range directions {
+				endX, endY := x+d[0]*(c.WinLength-1), y+d[1]*(c.WinLength-1)
+				if endX < 0 || endX >= c.Width || endY >= c.Height {
+					continue
+				}
+
+				line := make([]int32, c.WinLength)
+				for i := range line {
+					line[i] = int32((y+d[1]*i)*c.Width + x + d[0]*i)
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+// Reason describes how a game came to an end.
+type Reason int
+
+const (
+	// The game is still in progress.
+	ReasonNone Reason = iota
+	// A player completed a winning line.
+	ReasonLine
+	// The board is full and nobody won.
+	ReasonTie
+	// A player forfeited, for example by running out of time.
+	ReasonForfeit
+	// A player left the game.
+	ReasonDisconnect
+)
+
+// Outcome is the result of a game, if it has finished.
+type Outcome struct {
+	// True once the game has ended, for whatever reason.
+	Done bool
+	// The winner of the game, if any. Unspecified if it's a draw or the game is still in progress.
+	Winner api.Mark
+	// Winner board positions, if the game was won by completing a line.
+	WinnerPositions []int32
+	// How the game ended.
+	Reason Reason
+}
+
+// Game holds the board and turn order of a single game round.
+type Game struct {
+	config Config
+	// Every line that wins the game when filled with the same mark.
+	winningPositions [][]int32
+	// Indexes into winningPositions for each board position, so a move only checks the lines passing through it.
+	linesByPosition [][]int
+	// Current state of the board.
+	board []api.Mark
+	// Whose turn it currently is.
+	mark api.Mark
+	// The result of the game, once it is over.
+	outcome Outcome
+}
+
+// New sets up an empty board with X to move first. The config must have passed Validate.
+func New(config Config) *Game {
+	size := config.Width * config.Height
+	winningPositions := config.winningPositions()
+
+	linesByPosition := make([][]int, size)
+	for i, line := range winningPositions {
+		for _, pos := range line {
+			linesByPosition[pos] = append(linesByPosition[pos], i)
+		}
+	}
+
+	return &Game{
+		config:           config,
+		winningPositions: winningPositions,
+		linesByPosition:  linesByPosition,
+		board:            make([]api.Mark, size),
+		mark:             api.Mark_MARK_X,
+	}
+}
+
+// Config returns the board dimensions and win length the game was set up with.
+func (g *Game) Config() Config {
+	return g.config
+}
+
+// Clone returns an independent copy of the game, which can be played on without affecting the original.
+func (g *Game) Clone() *Game {
+	clone := *g
+	clone.board = g.Board()
+	return &clone
+}
+
+// Board returns a copy of the current state of the board.
+func (g *Game) Board() []api.Mark {
+	board := make([]api.Mark, len(g.board))
+	copy(board, g.board)
+	return board
+}
+
+// Mark returns whose turn it currently is.
+func (g *Game) Mark() api.Mark {
+	return g.mark
+}
+
+// Outcome returns the result of the game. Done is false while the game is still in progress.
+func (g *Game) Outcome() Outcome {
+	return g.outcome
+}
+
+// LegalMoves lists the positions the player whose turn it is may play.
+func (g *Game) LegalMoves() []int32 {
+	if g.outcome.Done {
+		return nil
+	}
+
+	moves := make([]int32, 0, len(g.board))
+	for pos, mark := range g.board {
+		if mark == api.Mark_MARK_UNSPECIFIED {
+			moves = append(moves, int32(pos))
+		}
+	}
+	return moves
+}
+
+// WinningMoves lists the empty positions where the given mark would complete a line. For the player whose turn it
+// is these are the winning moves, for their opponent the moves that have to be blocked.
+func (g *Game) WinningMoves(mark api.Mark) []int32 {
+	if g.outcome.Done {
+		return nil
+	}
+
+	var moves []int32
+	for pos, m := range g.board {
+		if m != api.Mark_MARK_UNSPECIFIED {
+			continue
+		}
+		for _, i := range g.linesByPosition[pos] {
+			complete := true
+			for _, linePos := range g.winningPositions[i] {
+				if linePos != int32(pos) && g.board[linePos] != mark {
+					complete = false
+					break
+				}
+			}
+			if complete {
+				moves = append(moves, int32(pos))
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// ApplyMove places the given mark on the board, checks for a winner or a tie, and passes the turn to the opponent.
+// The board is left untouched if the move is not allowed.
+func (g *Game) ApplyMove(mark api.Mark, position int32) error {
+	if g.outcome.Done {
+		return ErrGameOver
+	}
+	if g.mark != mark {
+		return ErrNotYourTurn
+	}
+	if position < 0 || int(position) >= len(g.board) {
+		return ErrInvalidPosition
+	}
+	if g.board[position] != api.Mark_MARK_UNSPECIFIED {
+		return ErrPositionTaken
+	}
+
+	g.board[position] = mark
+	g.mark = Opponent(mark)
+
+	for _, i := range g.linesByPosition[position] {
+		winningPosition := g.winningPositions[i]
+		if g.lineComplete(winningPosition, mark) {
+			g.outcome = Outcome{
+				Done:            true,
+				Winner:          mark,
+				WinnerPositions: winningPosition,
+				Reason:          ReasonLine,
+			}
+			return nil
+		}
+	}
+
+	for _, m := range g.board {
+		if m == api.Mark_MARK_UNSPECIFIED {
+			return nil
+		}
+	}
+	g.outcome = Outcome{
+		Done:   true,
+		Reason: ReasonTie,
+	}
+	return nil
+}
+
+// Forfeit ends the game in favour of the opponent of the given mark.
+func (g *Game) Forfeit(mark api.Mark) {
+	g.concede(mark, ReasonForfeit)
+}
+
+// Disconnect ends the game in favour of the opponent of the given mark, who has left the game.
+func (g *Game) Disconnect(mark api.Mark) {
+	g.concede(mark, ReasonDisconnect)
+}
+
+func (g *Game) concede(mark api.Mark, reason Reason) {
+	if g.outcome.Done {
+		return
+	}
+
+	g.outcome = Outcome{
+		Done:   true,
+		Winner: Opponent(mark),
+		Reason: reason,
+	}
+}
+
+func (g *Game) lineComplete(line []int32, mark api.Mark) bool {
+	for _, pos := range line {
+		if g.board[pos] != mark {
+			return false
+		}
+	}
+	return true
+}
+
+// Opponent returns the mark playing against the given one.
+func Opponent(mark api.Mark) api.Mark {
+	switch mark {
+	case api.Mark_MARK_X:
+		return api.Mark_MARK_O
+	case api.Mark_MARK_O:
+		return api.Mark_MARK_X
+	default:
+		return api.Mark_MARK_UNSPECIFIED
+	}
+}
@@ -0,0 +1,346 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const (
+	x = api.Mark_MARK_X
+	o = api.Mark_MARK_O
+)
+
+// Play the positions in turn, X first, failing the test on any rejected move.
+func play(t *testing.T, g *Game, positions ...int32) {
+	t.Helper()
+	for _, pos := range positions {
+		if err := g.ApplyMove(g.Mark(), pos); err != nil {
+			t.Fatalf("move %d: %v", pos, err)
+		}
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		valid  bool
+	}{
+		{"classic", DefaultConfig(), true},
+		{"gomoku", Config{Width: 15, Height: 15, WinLength: 5}, true},
+		{"largest", Config{Width: MaxSize, Height: MaxSize, WinLength: 5}, true},
+		{"rectangular", Config{Width: 7, Height: 3, WinLength: 5}, true},
+		{"too narrow", Config{Width: 2, Height: 3, WinLength: 3}, false},
+		{"too tall", Config{Width: 3, Height: MaxSize + 1, WinLength: 3}, false},
+		{"win length too short", Config{Width: 3, Height: 3, WinLength: 2}, false},
+		{"line doesn't fit", Config{Width: 4, Height: 4, WinLength: 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestWinningPositions(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		// Rows, columns, and each of the two diagonal directions.
+		rows, columns, diagonals int
+	}{
+		{"classic", DefaultConfig(), 3, 3, 1},
+		{"4x4 three in a row", Config{Width: 4, Height: 4, WinLength: 3}, 8, 8, 4},
+		{"gomoku", Config{Width: 15, Height: 15, WinLength: 5}, 165, 165, 121},
+		{"wide", Config{Width: 5, Height: 3, WinLength: 3}, 9, 5, 3},
+		{"tall", Config{Width: 4, Height: 6, WinLength: 4}, 6, 12, 3},
+		{"rows only", Config{Width: 7, Height: 3, WinLength: 5}, 9, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.config
+			var rows, columns, diagonals, antiDiagonals int
+			seen := make(map[string]bool)
+			for _, line := range c.winningPositions() {
+				if len(line) != c.WinLength {
+					t.Fatalf("line %v has %d cells, want %d", line, len(line), c.WinLength)
+				}
+				key := lineKey(line)
+				if seen[key] {
+					t.Fatalf("line %v listed twice", line)
+				}
+				seen[key] = true
+
+				// Every step along a line moves the same way, by one cell, without wrapping round an edge.
+				x0, y0 := int(line[0])%c.Width, int(line[0])/c.Width
+				x1, y1 := int(line[1])%c.Width, int(line[1])/c.Width
+				dx, dy := x1-x0, y1-y0
+				for i, pos := range line {
+					if pos < 0 || int(pos) >= c.Width*c.Height {
+						t.Fatalf("line %v leaves the board", line)
+					}
+					if px, py := int(pos)%c.Width, int(pos)/c.Width; px != x0+dx*i || py != y0+dy*i {
+						t.Fatalf("line %v isn't straight", line)
+					}
+				}
+
+				switch [2]int{dx, dy} {
+				case [2]int{1, 0}:
+					rows++
+				case [2]int{0, 1}:
+					columns++
+				case [2]int{1, 1}:
+					diagonals++
+				case [2]int{-1, 1}:
+					antiDiagonals++
+				default:
+					t.Fatalf("line %v runs in direction (%d, %d)", line, dx, dy)
+				}
+			}
+
+			if rows != tt.rows || columns != tt.columns || diagonals != tt.diagonals || antiDiagonals != tt.diagonals {
+				t.Errorf("got %d rows, %d columns, %d and %d diagonals, want %d, %d, %d and %d",
+					rows, columns, diagonals, antiDiagonals, tt.rows, tt.columns, tt.diagonals, tt.diagonals)
+			}
+		})
+	}
+}
+
+func TestClassicWinningPositions(t *testing.T) {
+	want := [][]int32{
+		{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+		{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+		{0, 4, 8}, {2, 4, 6},
+	}
+	got := DefaultConfig().winningPositions()
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(got), len(want))
+	}
+	for _, line := range want {
+		if !slices.ContainsFunc(got, func(l []int32) bool { return slices.Equal(l, line) }) {
+			t.Errorf("missing line %v", line)
+		}
+	}
+}
+
+func lineKey(line []int32) string {
+	key := make([]byte, 0, len(line))
+	for _, pos := range line {
+		key = append(key, byte(pos), byte(pos>>8))
+	}
+	return string(key)
+}
+
+func TestApplyMoveRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		moves    []int32
+		mark     api.Mark
+		position int32
+		err      error
+	}{
+		{"out of turn", nil, o, 4, ErrNotYourTurn},
+		{"no mark", nil, api.Mark_MARK_UNSPECIFIED, 4, ErrNotYourTurn},
+		{"negative position", nil, x, -1, ErrInvalidPosition},
+		{"past the end", nil, x, 9, ErrInvalidPosition},
+		{"taken by the opponent", []int32{4}, o, 4, ErrPositionTaken},
+		{"taken by the player", []int32{4, 0}, x, 4, ErrPositionTaken},
+		{"game over", []int32{0, 3, 1, 4, 2}, o, 8, ErrGameOver},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(DefaultConfig())
+			play(t, g, tt.moves...)
+			board, mark := g.Board(), g.Mark()
+
+			if err := g.ApplyMove(tt.mark, tt.position); !errors.Is(err, tt.err) {
+				t.Fatalf("ApplyMove() = %v, want %v", err, tt.err)
+			}
+			if !slices.Equal(g.Board(), board) || g.Mark() != mark {
+				t.Errorf("rejected move changed the game")
+			}
+		})
+	}
+}
+
+func TestWin(t *testing.T) {
+	gomoku := Config{Width: 15, Height: 15, WinLength: 5}
+	wide := Config{Width: 5, Height: 3, WinLength: 3}
+	tests := []struct {
+		name      string
+		config    Config
+		moves     []int32
+		winner    api.Mark
+		positions []int32
+	}{
+		{"top row", DefaultConfig(), []int32{0, 3, 1, 4, 2}, x, []int32{0, 1, 2}},
+		{"bottom row for O", DefaultConfig(), []int32{0, 6, 1, 7, 5, 8}, o, []int32{6, 7, 8}},
+		{"middle column", DefaultConfig(), []int32{1, 0, 4, 2, 7}, x, []int32{1, 4, 7}},
+		{"diagonal", DefaultConfig(), []int32{0, 1, 4, 2, 8}, x, []int32{0, 4, 8}},
+		{"anti-diagonal", DefaultConfig(), []int32{2, 0, 4, 1, 6}, x, []int32{2, 4, 6}},
+		{"gomoku row", gomoku, []int32{100, 0, 101, 1, 102, 2, 103, 3, 104}, x, []int32{100, 101, 102, 103, 104}},
+		{"gomoku diagonal", gomoku, []int32{0, 1, 16, 2, 32, 3, 48, 4, 64}, x, []int32{0, 16, 32, 48, 64}},
+		{"gomoku anti-diagonal at the edge", gomoku, []int32{14, 0, 28, 1, 42, 2, 56, 3, 70}, x, []int32{14, 28, 42, 56, 70}},
+		{"wide row at the right edge", wide, []int32{2, 0, 3, 5, 4}, x, []int32{2, 3, 4}},
+		{"wide anti-diagonal", wide, []int32{4, 0, 8, 1, 12}, x, []int32{4, 8, 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(tt.config)
+			play(t, g, tt.moves...)
+
+			want := Outcome{Done: true, Winner: tt.winner, WinnerPositions: tt.positions, Reason: ReasonLine}
+			if got := g.Outcome(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Outcome() = %+v, want %+v", got, want)
+			}
+			if moves := g.LegalMoves(); moves != nil {
+				t.Errorf("LegalMoves() = %v after the game is over", moves)
+			}
+		})
+	}
+}
+
+func TestNoWinAcrossEdge(t *testing.T) {
+	// 2, 3 and 4 are consecutive positions, but 2 ends the first row and 3 starts the second.
+	g := New(DefaultConfig())
+	play(t, g, 2, 0, 3, 1, 4)
+	if g.Outcome().Done {
+		t.Errorf("Outcome() = %+v, a line wrapping round the edge doesn't win", g.Outcome())
+	}
+}
+
+func TestTie(t *testing.T) {
+	g := New(DefaultConfig())
+	// X O X
+	// X O O
+	// O X X
+	play(t, g, 0, 1, 2, 4, 3, 5, 7, 6, 8)
+
+	want := Outcome{Done: true, Reason: ReasonTie}
+	if got := g.Outcome(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Outcome() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWinOnLastMove(t *testing.T) {
+	g := New(DefaultConfig())
+	// X O X
+	// O X O
+	// O X X, filling the board and completing a line at once.
+	play(t, g, 0, 1, 2, 3, 7, 5, 4, 6, 8)
+
+	want := Outcome{Done: true, Winner: x, WinnerPositions: []int32{0, 4, 8}, Reason: ReasonLine}
+	if got := g.Outcome(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Outcome() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConcede(t *testing.T) {
+	tests := []struct {
+		name    string
+		concede func(*Game, api.Mark)
+		reason  Reason
+	}{
+		{"forfeit", (*Game).Forfeit, ReasonForfeit},
+		{"disconnect", (*Game).Disconnect, ReasonDisconnect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(DefaultConfig())
+			play(t, g, 4)
+			tt.concede(g, o)
+
+			want := Outcome{Done: true, Winner: x, Reason: tt.reason}
+			if got := g.Outcome(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Outcome() = %+v, want %+v", got, want)
+			}
+			if err := g.ApplyMove(o, 0); !errors.Is(err, ErrGameOver) {
+				t.Errorf("ApplyMove() = %v after conceding, want %v", err, ErrGameOver)
+			}
+		})
+
+		t.Run(tt.name+" after the game is over", func(t *testing.T) {
+			g := New(DefaultConfig())
+			play(t, g, 0, 3, 1, 4, 2)
+			before := g.Outcome()
+			tt.concede(g, x)
+			if got := g.Outcome(); !reflect.DeepEqual(got, before) {
+				t.Errorf("Outcome() = %+v, conceding a finished game changed it from %+v", got, before)
+			}
+		})
+	}
+}
+
+func TestWinningMoves(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []int32
+		mark  api.Mark
+		want  []int32
+	}{
+		{"empty board", nil, x, nil},
+		{"one row to complete", []int32{0, 3, 1}, x, []int32{2}},
+		{"the opponent's block", []int32{0, 3, 1}, o, nil},
+		{"fork", []int32{0, 1, 4, 2, 6}, x, []int32{3, 8}},
+		{"blocked line", []int32{0, 1, 3, 6}, x, nil},
+		{"to block", []int32{4, 0, 8, 1}, o, []int32{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(DefaultConfig())
+			play(t, g, tt.moves...)
+			if got := g.WinningMoves(tt.mark); !slices.Equal(got, tt.want) {
+				t.Errorf("WinningMoves(%v) = %v, want %v", tt.mark, got, tt.want)
+			}
+		})
+	}
+
+	g := New(DefaultConfig())
+	play(t, g, 0, 3, 1, 4, 2)
+	if got := g.WinningMoves(o); got != nil {
+		t.Errorf("WinningMoves() = %v after the game is over", got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := New(DefaultConfig())
+	play(t, g, 4)
+	clone := g.Clone()
+	play(t, clone, 0, 8, 1)
+
+	if want := []api.Mark{0, 0, 0, 0, x, 0, 0, 0, 0}; !slices.Equal(g.Board(), want) {
+		t.Errorf("playing on the clone changed the original board to %v", g.Board())
+	}
+	if g.Mark() != o {
+		t.Errorf("playing on the clone changed whose turn it is to %v", g.Mark())
+	}
+
+	play(t, clone, 2)
+	play(t, g, 0, 1, 2, 7)
+	if !g.Outcome().Done || clone.Outcome().Done {
+		t.Errorf("games didn't finish independently: original %+v, clone %+v", g.Outcome(), clone.Outcome())
+	}
+}
+
+func TestBoardIsACopy(t *testing.T) {
+	g := New(DefaultConfig())
+	board := g.Board()
+	board[0] = o
+	if err := g.ApplyMove(x, 0); err != nil {
+		t.Errorf("changing a copy of the board changed the game: %v", err)
+	}
+}
+
+func TestOpponent(t *testing.T) {
+	for mark, want := range map[api.Mark]api.Mark{x: o, o: x, api.Mark_MARK_UNSPECIFIED: api.Mark_MARK_UNSPECIFIED} {
+		if got := Opponent(mark); got != want {
+			t.Errorf("Opponent(%v) = %v, want %v", mark, got, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+	"github.com/heroiclabs/nakama-project-template/notation"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Export a finished game in the game notation, to share it or attach it to a bug report.
+func rpcExportGame(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcExportGameRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		record, err := readGameRecord(ctx, nk, request.ReplayId)
+		if err != nil {
+			if errors.Is(err, errGameRecordNotFound) {
+				return "", errReplayNotFound
+			}
+			logger.Error("error reading game record: %v", err)
+			return "", errInternalError
+		}
+
+		text, err := record.notationGame().MarshalText()
+		if err != nil {
+			logger.Error("error writing game record %s in notation: %v", request.ReplayId, err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(&api.RpcExportGameResponse{Notation: string(text)})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Read a game written in the game notation, and return it as get_replay would, so a shared game can be played back.
+func rpcImportGame(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcImportGameRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		g, err := notation.Parse([]byte(request.Notation))
+		if err != nil {
+			logger.Debug("error parsing game notation: %v", err)
+			return "", errBadInput
+		}
+
+		record := gameRecordFromNotation(g)
+		frames, done, err := replayFrames(record)
+		if err != nil {
+			logger.Error("error replaying imported game: %v", err)
+			return "", errInternalError
+		}
+
+		// The game hasn't been stored, so there's nothing to refer back to.
+		history := record.historyGame()
+		history.ReplayId = ""
+
+		response, err := marshaler.Marshal(&api.RpcGetReplayResponse{
+			Game:   history,
+			Frames: frames,
+			Done:   done,
+		})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// The recorded round in the game notation.
+func (r *gameRecord) notationGame() *notation.Game {
+	g := &notation.Game{
+		Config:  game.Config{Width: r.Width, Height: r.Height, WinLength: r.WinLength},
+		Fast:    r.Fast,
+		Ranked:  r.Ranked,
+		MatchID: r.MatchID,
+		Round:   r.Round,
+		Start:   unixMilli(r.StartedAt),
+		End:     unixMilli(r.EndedAt),
+		Moves:   make([]notation.Move, 0, len(r.Moves)),
+		Winner:  r.Winner,
+	}
+	for _, player := range r.Players {
+		p := notation.Player{Name: player.Username, ID: player.UserID, Rating: player.Rating}
+		switch player.Mark {
+		case api.Mark_MARK_X:
+			g.X = p
+		case api.Mark_MARK_O:
+			g.O = p
+		}
+	}
+	for _, move := range r.Moves {
+		g.Moves = append(g.Moves, notation.Move{Position: move.Position, Time: unixMilli(move.Time)})
+	}
+	for reason, name := range gameRecordReasons {
+		if name == r.Reason {
+			g.Termination = reason
+		}
+	}
+	return g
+}
+
+// A game read from the notation as a round record, the way it would have been stored had it been played here.
+func gameRecordFromNotation(g *notation.Game) *gameRecord {
+	record := &gameRecord{
+		MatchID:   g.MatchID,
+		Round:     g.Round,
+		Fast:      g.Fast,
+		Ranked:    g.Ranked,
+		Width:     g.Config.Width,
+		Height:    g.Config.Height,
+		WinLength: g.Config.WinLength,
+		Players: []gameRecordPlayer{
+			{UserID: g.X.ID, Username: g.X.Name, Mark: api.Mark_MARK_X, Rating: g.X.Rating},
+			{UserID: g.O.ID, Username: g.O.Name, Mark: api.Mark_MARK_O, Rating: g.O.Rating},
+		},
+		Moves:     make([]gameMove, 0, len(g.Moves)),
+		Winner:    g.Winner,
+		Reason:    gameRecordReasons[g.Termination],
+		StartedAt: timeMilli(g.Start),
+		EndedAt:   timeMilli(g.End),
+	}
+	// X moves first, then the players take turns.
+	mark := api.Mark_MARK_X
+	for _, move := range g.Moves {
+		record.Moves = append(record.Moves, gameMove{Position: move.Position, Mark: mark, Time: timeMilli(move.Time)})
+		mark = game.Opponent(mark)
+	}
+	return record
+}
+
+// Zero in a record stands for a time that isn't known.
+func unixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+func timeMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/bot"
+	"github.com/heroiclabs/nakama-project-template/game"
+	"github.com/heroiclabs/nakama-project-template/tfrecord"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Every finished round, owned by the system user.
+	gameRecordCollection = "game_records"
+	// Each player's own copy of the rounds they played, only readable by them.
+	matchHistoryCollection = "match_history"
+
+	defaultExportLimit = 100
+	maxExportLimit     = 1000
+)
+
+var gameRecordReasons = map[game.Reason]string{
+	game.ReasonLine:       "line",
+	game.ReasonTie:        "tie",
+	game.ReasonForfeit:    "forfeit",
+	game.ReasonDisconnect: "disconnect",
+}
+
+// A finished round, with everything needed to replay it and learn from it.
+type gameRecord struct {
+	MatchID   string             `json:"match_id"`
+	Round     int32              `json:"round"`
+	Fast      bool               `json:"fast"`
+	Ranked    bool               `json:"ranked"`
+	Width     int                `json:"width"`
+	Height    int                `json:"height"`
+	WinLength int                `json:"win_length"`
+	Players   []gameRecordPlayer `json:"players"`
+	Moves     []gameMove         `json:"moves"`
+	// Unspecified for a draw.
+	Winner    api.Mark `json:"winner"`
+	Reason    string   `json:"reason"`
+	StartedAt int64    `json:"started_at"`
+	EndedAt   int64    `json:"ended_at"`
+}
+
+type gameRecordPlayer struct {
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Mark     api.Mark `json:"mark"`
+	// Rating going into the round.
+	Rating float64 `json:"rating"`
+}
+
+// A move played in a round, and when, in Unix milliseconds.
+type gameMove struct {
+	Position int32    `json:"position"`
+	Mark     api.Mark `json:"mark"`
+	Time     int64    `json:"time"`
+}
+
+// A training example for a single move made by a human player: the board they saw in the model's input format, the
+// position they played, and how the round turned out for them, 1 for a win, -1 for a loss and 0 for a draw.
+type trainingExample struct {
+	Instance bot.Board `json:"instance"`
+	Label    int32     `json:"label"`
+	Result   int       `json:"result"`
+	MatchID  string    `json:"match_id"`
+	UserID   string    `json:"user_id"`
+	Rating   float64   `json:"rating"`
+	Fast     bool      `json:"fast"`
+	Ranked   bool      `json:"ranked"`
+}
+
+// Store the round that has just finished, add it to each player's match history, and count it in their stats. Failing
+// to is logged, but doesn't hold up the match.
+func recordGame(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, s *MatchState) {
+	matchID, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
+	outcome := s.game.Outcome()
+	config := s.game.Config()
+
+	record := &gameRecord{
+		MatchID:   matchID,
+		Round:     s.roundNumber,
+		Fast:      s.label.Fast == 1,
+		Ranked:    s.label.Ranked == 1,
+		Width:     config.Width,
+		Height:    config.Height,
+		WinLength: config.WinLength,
+		Players:   make([]gameRecordPlayer, 0, len(s.marks)),
+		Moves:     s.moves,
+		Winner:    outcome.Winner,
+		Reason:    gameRecordReasons[outcome.Reason],
+		StartedAt: s.roundStartedAt.UnixMilli(),
+		EndedAt:   time.Now().UTC().UnixMilli(),
+	}
+	humanIDs := make([]string, 0, len(s.marks))
+	for userID := range s.marks {
+		if userID != aiUserId {
+			humanIDs = append(humanIDs, userID)
+		}
+	}
+	usernames := map[string]string{aiUserId: aiPresenceObj.GetUsername()}
+	users, err := nk.UsersGetId(ctx, humanIDs, nil)
+	if err != nil {
+		logger.Error("error getting users: %v", err)
+		return
+	}
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+
+	for userID, mark := range s.marks {
+		rating := aiRating
+		if userID != aiUserId {
+			if rating, err = readRating(ctx, nk, userID, record.Fast); err != nil {
+				logger.Error("error reading rating: %v", err)
+				return
+			}
+		}
+		record.Players = append(record.Players, gameRecordPlayer{
+			UserID:   userID,
+			Username: usernames[userID],
+			Mark:     mark,
+			Rating:   rating.Rating,
+		})
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		logger.Error("error encoding game record: %v", err)
+		return
+	}
+	key := gameRecordKey(record)
+	writes := []*runtime.StorageWrite{{
+		Collection:      gameRecordCollection,
+		Key:             key,
+		Value:           string(value),
+		PermissionRead:  0,
+		PermissionWrite: 0,
+	}}
+	for _, userID := range humanIDs {
+		writes = append(writes, &runtime.StorageWrite{
+			Collection:      matchHistoryCollection,
+			Key:             key,
+			UserID:          userID,
+			Value:           string(value),
+			PermissionRead:  1,
+			PermissionWrite: 0,
+		})
+	}
+	if _, err := nk.StorageWrite(ctx, writes); err != nil {
+		logger.Error("error writing game record: %v", err)
+	}
+
+	updatePlayerStats(ctx, nk, logger, record)
+}
+
+// Storage lists objects in key order, so counting down from the latest possible time puts the most recent rounds
+// first.
+func gameRecordKey(record *gameRecord) string {
+	return fmt.Sprintf("%019d.%s.%d", math.MaxInt64-record.EndedAt, record.MatchID, record.Round)
+}
+
+// Turn a recorded round into one training example for every move a human player made in it.
+func trainingExamples(record *gameRecord) ([]*trainingExample, error) {
+	config := game.Config{Width: record.Width, Height: record.Height, WinLength: record.WinLength}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	players := make(map[api.Mark]gameRecordPlayer, len(record.Players))
+	for _, player := range record.Players {
+		players[player.Mark] = player
+	}
+
+	g := game.New(config)
+	examples := make([]*trainingExample, 0, len(record.Moves))
+	for _, move := range record.Moves {
+		if player, ok := players[move.Mark]; ok && player.UserID != aiUserId {
+			result := 0
+			switch record.Winner {
+			case move.Mark:
+				result = 1
+			case game.Opponent(move.Mark):
+				result = -1
+			}
+
+			examples = append(examples, &trainingExample{
+				Instance: bot.EncodeBoard(g, move.Mark),
+				Label:    move.Position,
+				Result:   result,
+				MatchID:  record.MatchID,
+				UserID:   player.UserID,
+				Rating:   player.Rating,
+				Fast:     record.Fast,
+				Ranked:   record.Ranked,
+			})
+		}
+
+		if err := g.ApplyMove(move.Mark, move.Position); err != nil {
+			return nil, err
+		}
+	}
+	return examples, nil
+}
+
+// The same user always gets the same stand-in ID, so their games can still be told apart, but not traced back to them
+// without the salt.
+func anonymiseUserID(salt, userID string) string {
+	sum := sha256.Sum256([]byte(salt + userID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Export recorded rounds as training data for the AI, a page at a time. Only callable server to server, with the
+// runtime HTTP key.
+func rpcExportTrainingData(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, salt string) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		if userID, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string); userID != "" {
+			return "", errServerOnly
+		}
+
+		request := &api.RpcExportTrainingDataRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		if request.Anonymise && salt == "" {
+			// Unsalted, the stand-in IDs could be matched up with a list of user IDs by hashing them all.
+			logger.Warn("TRAINING_DATA_SALT isn't set, refusing to export anonymised training data")
+			return "", errNoSalt
+		}
+
+		limit := defaultExportLimit
+		if request.Limit > 0 && request.Limit <= maxExportLimit {
+			limit = int(request.Limit)
+		}
+
+		objects, cursor, err := nk.StorageList(ctx, "", "", gameRecordCollection, limit, request.Cursor)
+		if err != nil {
+			logger.Error("error listing game records: %v", err)
+			return "", errInternalError
+		}
+
+		var data bytes.Buffer
+		response := &api.RpcExportTrainingDataResponse{Cursor: cursor}
+		for _, object := range objects {
+			record := &gameRecord{}
+			if err := json.Unmarshal([]byte(object.Value), record); err != nil {
+				logger.Warn("error decoding game record %s: %v", object.Key, err)
+				continue
+			}
+			examples, err := trainingExamples(record)
+			if err != nil {
+				logger.Warn("error replaying game record %s: %v", object.Key, err)
+				continue
+			}
+
+			for _, example := range examples {
+				if request.Anonymise {
+					example.UserID = anonymiseUserID(salt, example.UserID)
+				}
+
+				switch request.Format {
+				case api.TrainingDataFormat_TRAINING_DATA_FORMAT_TFRECORD:
+					data.Write(tfrecord.AppendRecord(nil, example.tfExample().Marshal()))
+				default:
+					line, err := json.Marshal(example)
+					if err != nil {
+						logger.Error("error encoding training example: %v", err)
+						return "", errMarshal
+					}
+					data.Write(line)
+					data.WriteByte('\n')
+				}
+			}
+			response.Games++
+			response.Examples += int32(len(examples))
+		}
+		response.Data = data.Bytes()
+
+		out, err := marshaler.Marshal(response)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(out), nil
+	}
+}
+
+// The example as a tf.train.Example. The board is flattened, with its shape alongside to restore it.
+func (e *trainingExample) tfExample() tfrecord.Example {
+	var instance []float32
+	for _, row := range e.Instance {
+		for _, cell := range row {
+			instance = append(instance, float32(cell[0]), float32(cell[1]))
+		}
+	}
+
+	return tfrecord.Example{
+		"instance":       {Floats: instance},
+		"instance_shape": {Ints: []int64{int64(len(e.Instance)), int64(len(e.Instance[0])), 2}},
+		"label":          {Ints: []int64{int64(e.Label)}},
+		"result":         {Ints: []int64{int64(e.Result)}},
+		"match_id":       {Bytes: [][]byte{[]byte(e.MatchID)}},
+		"user_id":        {Bytes: [][]byte{[]byte(e.UserID)}},
+		"rating":         {Floats: []float32{float32(e.Rating)}},
+		"fast":           {Ints: []int64{boolInt(e.Fast)}},
+		"ranked":         {Ints: []int64{boolInt(e.Ranked)}},
+	}
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+// Package glicko2 implements the Glicko-2 rating system, as described by Mark Glickman in
+// http://www.glicko.net/glicko/glicko2.pdf. Every game is treated as its own rating period.
+package glicko2
+
+import (
+	"math"
+)
+
+const (
+	// Ratings given to players who have never played a rated game.
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+
+	// Constrains how much the volatility can change from one game to the next.
+	tau = 0.5
+	// Convergence tolerance of the volatility iteration.
+	epsilon = 0.000001
+	// Converts between the Glicko and Glicko-2 scales.
+	scale = 173.7178
+)
+
+// Rating is a player's skill estimate: the rating itself, how uncertain it is, and how erratic their results are.
+type Rating struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+}
+
+// Default returns the rating of a brand-new player.
+func Default() Rating {
+	return Rating{
+		Rating:     DefaultRating,
+		Deviation:  DefaultDeviation,
+		Volatility: DefaultVolatility,
+	}
+}
+
+// Result is the outcome of one game from the player's side: 1 for a win, 0.5 for a draw and 0 for a loss.
+type Result struct {
+	Opponent Rating
+	Score    float64
+}
+
+// Update returns the player's new rating after a game against the opponent. Score is 1 for a win, 0.5 for a draw
+// and 0 for a loss. The opponent's rating is not changed, call Update again from their side with 1 - score.
+func Update(player, opponent Rating, score float64) Rating {
+	return UpdatePeriod(player, []Result{{Opponent: opponent, Score: score}})
+}
+
+// UpdatePeriod returns the player's new rating after a rating period with the given games, all rated against the
+// opponents' ratings at the start of the period. With no games only the deviation grows.
+func UpdatePeriod(player Rating, results []Result) Rating {
+	mu := (player.Rating - DefaultRating) / scale
+	phi := player.Deviation / scale
+	sigma := player.Volatility
+
+	if len(results) == 0 {
+		return Rating{
+			Rating:     player.Rating,
+			Deviation:  math.Min(math.Sqrt(phi*phi+sigma*sigma)*scale, DefaultDeviation),
+			Volatility: sigma,
+		}
+	}
+
+	// Steps 3 and 4 of the paper: the estimated variance from the games, and the improvement they show.
+	var vInv, improvement float64
+	for _, r := range results {
+		opponentMu := (r.Opponent.Rating - DefaultRating) / scale
+		opponentPhi := r.Opponent.Deviation / scale
+
+		g := 1 / math.Sqrt(1+3*opponentPhi*opponentPhi/(math.Pi*math.Pi))
+		e := 1 / (1 + math.Exp(-g*(mu-opponentMu)))
+		vInv += g * g * e * (1 - e)
+		improvement += g * (r.Score - e)
+	}
+	v := 1 / vInv
+	delta := v * improvement
+
+	sigma = volatility(delta, phi, v, sigma)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu = mu + phi*phi*improvement
+
+	return Rating{
+		Rating:     mu*scale + DefaultRating,
+		Deviation:  math.Min(phi*scale, DefaultDeviation),
+		Volatility: sigma,
+	}
+}
+
+// Find the new volatility with the Illinois algorithm, step 5 of the paper.
+func volatility(delta, phi, v, sigma float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
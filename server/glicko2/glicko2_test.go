@@ -0,0 +1,96 @@
+package glicko2
+
+import (
+	"math"
+	"testing"
+)
+
+func near(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
+
+// The worked example from section 3 of Glickman's paper.
+func TestUpdatePeriodPaperExample(t *testing.T) {
+	player := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	results := []Result{
+		{Opponent: Rating{Rating: 1400, Deviation: 30, Volatility: 0.06}, Score: 1},
+		{Opponent: Rating{Rating: 1550, Deviation: 100, Volatility: 0.06}, Score: 0},
+		{Opponent: Rating{Rating: 1700, Deviation: 300, Volatility: 0.06}, Score: 0},
+	}
+
+	got := UpdatePeriod(player, results)
+	if !near(got.Rating, 1464.06, 0.01) || !near(got.Deviation, 151.52, 0.01) || !near(got.Volatility, 0.05999, 0.00001) {
+		t.Errorf("UpdatePeriod() = %+v, want 1464.06, 151.52, 0.05999", got)
+	}
+}
+
+// Step 5 of the paper on its own, from the example's intermediate values.
+func TestVolatilityPaperExample(t *testing.T) {
+	if got := volatility(-0.4834, 200/scale, 1.7785, 0.06); !near(got, 0.05999, 0.00001) {
+		t.Errorf("volatility() = %v, want 0.05999", got)
+	}
+}
+
+func TestUpdatePeriodNoGames(t *testing.T) {
+	player := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	got := UpdatePeriod(player, nil)
+	if got.Rating != player.Rating || got.Volatility != player.Volatility {
+		t.Errorf("UpdatePeriod() = %+v, want only the deviation changed", got)
+	}
+	// Step 6: sqrt(phi^2 + sigma^2) on the Glicko-2 scale.
+	if want := math.Sqrt(200*200 + 0.06*0.06*scale*scale); !near(got.Deviation, want, 1e-9) {
+		t.Errorf("deviation %v, want %v", got.Deviation, want)
+	}
+	if got := UpdatePeriod(Default(), nil); got.Deviation != DefaultDeviation {
+		t.Errorf("new player's deviation grew to %v, want capped at %v", got.Deviation, DefaultDeviation)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		player   Rating
+		opponent Rating
+		score    float64
+		// The sign of the rating change.
+		change int
+	}{
+		{"new players, win", Default(), Default(), 1, 1},
+		{"new players, loss", Default(), Default(), 0, -1},
+		{"new players, draw", Default(), Default(), 0.5, 0},
+		{"draw against a stronger player", Default(), Rating{Rating: 1800, Deviation: 50, Volatility: 0.06}, 0.5, 1},
+		{"loss against a weaker player", Rating{Rating: 1800, Deviation: 50, Volatility: 0.06}, Default(), 0, -1},
+		// An upset far beyond what the ratings predicted, which takes the other branch of step 5.
+		{"upset", Rating{Rating: 1200, Deviation: 30, Volatility: 0.06}, Rating{Rating: 2400, Deviation: 30, Volatility: 0.06}, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Update(tt.player, tt.opponent, tt.score)
+			switch change := got.Rating - tt.player.Rating; {
+			case tt.change > 0 && change <= 0, tt.change < 0 && change >= 0, tt.change == 0 && !near(change, 0, 1e-9):
+				t.Errorf("rating went from %v to %v", tt.player.Rating, got.Rating)
+			}
+			if got.Deviation <= 0 || got.Deviation > DefaultDeviation {
+				t.Errorf("deviation %v out of range", got.Deviation)
+			}
+			if math.IsNaN(got.Volatility) || got.Volatility <= 0 {
+				t.Errorf("volatility %v out of range", got.Volatility)
+			}
+			if want := UpdatePeriod(tt.player, []Result{{Opponent: tt.opponent, Score: tt.score}}); got != want {
+				t.Errorf("Update() = %+v, want the same as a period of one game, %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdateSymmetric(t *testing.T) {
+	// Between two players rated the same, the winner gains what the loser drops.
+	a := Rating{Rating: 1620, Deviation: 80, Volatility: 0.06}
+	winner, loser := Update(a, a, 1), Update(a, a, 0)
+	if !near(winner.Rating-a.Rating, a.Rating-loser.Rating, 1e-9) {
+		t.Errorf("winner went to %v, loser to %v, from %v", winner.Rating, loser.Rating, a.Rating)
+	}
+	if !near(winner.Deviation, loser.Deviation, 1e-9) {
+		t.Errorf("winner's deviation %v, loser's %v", winner.Deviation, loser.Deviation)
+	}
+}
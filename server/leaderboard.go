@@ -2,142 +2,269 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"math"
 	"strings"
 
 	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/glicko2"
 )
 
+const (
+	// Ranked games rate the players, and these hold their current ratings, one for each turn clock.
+	rankedNormalLeaderboardId = "tictactoe_ranked_normal"
+	rankedFastLeaderboardId   = "tictactoe_ranked_fast"
+	// Casual games and games against the AI aren't rated, these count the series won in them.
+	casualNormalLeaderboardId = "tictactoe_casual_normal"
+	casualFastLeaderboardId   = "tictactoe_casual_fast"
+	aiLeaderboardId           = "tictactoe_ai"
+	// Ranked series won this week and this season, reset on a schedule.
+	weeklyLeaderboardId = "tictactoe_weekly"
+	seasonLeaderboardId = "tictactoe_season"
+
+	ratingCollection    = "player_ratings"
+	ratingKeyNormal     = "glicko2"
+	ratingKeyFast       = "glicko2_fast"
+	ratingWriteAttempts = 3
+)
+
+type leaderboardConfig struct {
+	// True if the leaderboard holds ratings, rather than counting wins.
+	rated bool
+	// When the leaderboard resets, as a cron expression. Never if empty.
+	resetSchedule string
+}
+
+var leaderboards = map[string]leaderboardConfig{
+	rankedNormalLeaderboardId: {rated: true},
+	rankedFastLeaderboardId:   {rated: true},
+	casualNormalLeaderboardId: {},
+	casualFastLeaderboardId:   {},
+	aiLeaderboardId:           {},
+	// Every Monday at midnight UTC.
+	weeklyLeaderboardId: {resetSchedule: "0 0 * * 1"},
+	// At midnight UTC on the first of January, April, July and October.
+	seasonLeaderboardId: {resetSchedule: "0 0 1 1,4,7,10 *"},
+}
+
+// The AI plays at a fixed strength, rated as a solid player whose rating is well known.
+var aiRating = glicko2.Rating{
+	Rating:     1700,
+	Deviation:  50,
+	Volatility: glicko2.DefaultVolatility,
+}
+
 func InitLeaderboard(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger) error {
-	// This creates the leaderboard only if it doesn’t exist.
-	err := nk.LeaderboardCreate(ctx, "tictactoe_global", true, "desc", "incr", "",
-		nil, true)
-	if err != nil && !strings.Contains(err.Error(), "already exists") {
-		logger.Error("Error creating leaderboard: %v", err)
+	for id, config := range leaderboards {
+		// This creates the leaderboard only if it doesn’t exist. Ratings replace the last score, wins add to it.
+		operator := "incr"
+		if config.rated {
+			operator = "set"
+		}
+		err := nk.LeaderboardCreate(ctx, id, true, "desc", operator, config.resetSchedule,
+			nil, true)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			logger.Error("Error creating leaderboard: %v", err)
+			return err
+		}
+
+		logger.Info("Leaderboard '%s' initialized.", id)
+	}
+	return nil
+}
+
+// The leaderboard a match's results go on, depending on how it's played.
+func matchLeaderboardId(label *MatchLabel) string {
+	switch {
+	case label.Ai == 1:
+		return aiLeaderboardId
+	case label.Ranked == 1 && label.Fast == 1:
+		return rankedFastLeaderboardId
+	case label.Ranked == 1:
+		return rankedNormalLeaderboardId
+	case label.Fast == 1:
+		return casualFastLeaderboardId
+	default:
+		return casualNormalLeaderboardId
+	}
+}
+
+// Players have a rating for each turn clock.
+func ratingKey(fast bool) string {
+	if fast {
+		return ratingKeyFast
+	}
+	return ratingKeyNormal
+}
+
+// Record the result of a rated series for both players, update their ratings for its turn clock together, and put
+// the new ratings on the leaderboard. Score is the result for the first player: 1 for a win, 0.5 for a draw and 0
+// for a loss. The AI has a fixed rating and is never written to storage or the leaderboard.
+func updateRatings(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, leaderboardId string, fast bool, userIDs [2]string, score float64) error {
+	var ratings [2]glicko2.Rating
+	var err error
+	for attempt := 0; attempt < ratingWriteAttempts; attempt++ {
+		if ratings, err = writeRatings(ctx, nk, fast, userIDs, score); err == nil {
+			break
+		}
+		logger.Warn("retrying rating update for %v: %v", userIDs, err)
+	}
+	if err != nil {
+		logger.Error("Failed updating ratings for %v: %v", userIDs, err)
 		return err
 	}
 
-	logger.Info("Leaderboard 'tictactoe_global' initialized.")
+	var humanIDs []string
+	for _, userID := range userIDs {
+		if userID != aiUserId {
+			humanIDs = append(humanIDs, userID)
+		}
+	}
+	users, err := nk.UsersGetId(ctx, humanIDs, nil)
+	if err != nil {
+		logger.Error("Error fetching usernames for %v: %v", humanIDs, err)
+	}
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+
+	for i, userID := range userIDs {
+		if userID == aiUserId {
+			continue
+		}
+
+		logger.Info("Updating leaderboard for %s (rating=%.0f)", usernames[userID], ratings[i].Rating)
+
+		metadata := map[string]interface{}{
+			"reason":     "match_result",
+			"deviation":  ratings[i].Deviation,
+			"volatility": ratings[i].Volatility,
+		}
+		if _, err := nk.LeaderboardRecordWrite(ctx, leaderboardId, userID, usernames[userID],
+			int64(math.Round(ratings[i].Rating)), 0, metadata, nil); err != nil {
+			logger.Error("Failed updating leaderboard for user %s: %v", userID, err)
+			return err
+		}
+	}
+
 	return nil
 }
 
-func updatePlayerStats(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, userID string, wins, losses, ties int) (map[string]interface{}, error) {
-	metadata := map[string]interface{}{
-		"reason": "match_result",
+// Add a series won to the winner's count on unrated leaderboards. The AI's wins aren't counted.
+func recordSeriesWin(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, userID string, leaderboardIds ...string) error {
+	if userID == aiUserId {
+		return nil
 	}
 
-	account, err := nk.AccountGetId(ctx, userID)
+	users, err := nk.UsersGetId(ctx, []string{userID}, nil)
 	if err != nil {
 		logger.Error("Error fetching username for %s: %v", userID, err)
 	}
-	username := account.GetUser().GetUsername()
-
-	var operator, deltaScore int
-
-	if losses > 0 {
-		operator = 4
-		deltaScore = 5
-	} else if wins > 0{
-		operator = 3
-		deltaScore = 10
-	} else {
-		operator = 3
-		deltaScore = 0
-	}
-
-	logger.Info("Updating leaderboard for %s (delta=%d) ----", username, operator, deltaScore)
-
-	_, err = nk.LeaderboardRecordWrite(
-		ctx,
-		"tictactoe_global",
-		userID,
-		username,
-		int64(deltaScore),
-		0,
-		metadata,
-		&operator,
-	)
-	if err != nil {
-		logger.Error("Failed updating leaderboard for user %s: %v ----", userID, err)
-		return nil, err
+	username := ""
+	if len(users) > 0 {
+		username = users[0].Username
 	}
 
-	stats := map[string]interface{}{
-		"wins":   wins,
-		"losses": losses,
-		"ties":   ties,
+	for _, leaderboardId := range leaderboardIds {
+		if _, err := nk.LeaderboardRecordWrite(ctx, leaderboardId, userID, username, 1, 0,
+			map[string]interface{}{"reason": "match_result"}, nil); err != nil {
+			logger.Error("Failed updating leaderboard %s for user %s: %v", leaderboardId, userID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Read a player's current rating for a turn clock, or the default rating if they have never played a rated series
+// with it.
+func readRating(ctx context.Context, nk runtime.NakamaModule, userID string, fast bool) (glicko2.Rating, error) {
+	if userID == aiUserId {
+		return aiRating, nil
 	}
 
-	return stats, nil
+	rating := glicko2.Default()
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: ratingCollection,
+		Key:        ratingKey(fast),
+		UserID:     userID,
+	}})
+	if err != nil {
+		return rating, err
+	}
+	if len(objects) > 0 {
+		if err := json.Unmarshal([]byte(objects[0].Value), &rating); err != nil {
+			return rating, err
+		}
+	}
+	return rating, nil
 }
 
-// // Fetch player stats or initialize them if not found
-// func getPlayerStats(ctx context.Context, nk runtime.NakamaModule, userID string) (map[string]int, error, any) {
-// 	readReq := []*runtime.StorageRead{
-// 		{
-// 			Collection: "player_stats",
-// 			Key:        "summary",
-// 			UserID:     userID,
-// 		},
-// 	}
-
-// 	records, err := nk.StorageRead(ctx, readReq)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	stats := map[string]int{"wins": 0, "losses": 0, "total": 0}
-// 	if len(records) > 0 && records[0].Value != "" {
-// 		if err := json.Unmarshal([]byte(records[0].Value), &stats); err != nil {
-// 			return nil, err
-// 		}
-// 	}
-// 	return stats, nil, records
-// }
-
-// // Update and persist stats to Nakama storage
-// func updatePlayerStats(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, userID string, deltaWins, deltaLosses, deltaTotal int) (map[string]int, error) {
-// 	stats, err, records := getPlayerStats(ctx, nk, userID)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	logger.Warn("records---- %v", records)
-
-// 	stats["wins"] += deltaWins
-// 	stats["losses"] += deltaLosses
-// 	stats["total"] += deltaTotal
-
-// 	statsJSON, _ := json.Marshal(stats)
-
-// 	write := []*runtime.StorageWrite{
-// 		{
-// 			Collection:      "player_stats",
-// 			Key:             "summary",
-// 			UserID:          userID,
-// 			Value:           string(statsJSON),
-// 			PermissionRead:  2, // readable by friends or public
-// 			PermissionWrite: 1, // only owner can write
-// 		},
-// 	}
-
-// 	if _, err := nk.StorageWrite(ctx, write); err != nil {
-// 		return nil, err
-// 	}
-
-// 	score := int64(deltaWins*9 - deltaLosses*6 + deltaTotal)
-
-// 	users, err := nk.UsersGetUsername(ctx, []string{userID})
-// 	if err != nil {
-// 		logger.Error("Couldn't get user name from id: %v", err)
-// 	}
-// 	var userName string
-// 	if len(users) > 0 {
-// 		userName = users[0].Username
-// 	}
-// 	logger.Error("stats-- %v , ---userId %v , -----usrs %v ", stats, userID, users)
-// 	if _, err := nk.LeaderboardRecordWrite(ctx, "tictactoe_global", userID, userName, score, score, map[string]interface{}{"stats": stats}, nil); err != nil {
-// 		logger.Error("error updating leaderboard for winner: %v", err)
-// 	}
-
-// 	return stats, nil
-// }
+// Read both players' ratings, rate the result, and write the new ratings back in a single storage write. The write
+// is conditional on the versions read, so it fails rather than lose a concurrent update to either player.
+func writeRatings(ctx context.Context, nk runtime.NakamaModule, fast bool, userIDs [2]string, score float64) ([2]glicko2.Rating, error) {
+	var ratings [2]glicko2.Rating
+	var versions [2]string
+
+	reads := make([]*runtime.StorageRead, 0, 2)
+	for i, userID := range userIDs {
+		ratings[i] = glicko2.Default()
+		// A version of "*" only writes the object if it doesn't exist yet.
+		versions[i] = "*"
+		if userID == aiUserId {
+			ratings[i] = aiRating
+			continue
+		}
+		reads = append(reads, &runtime.StorageRead{
+			Collection: ratingCollection,
+			Key:        ratingKey(fast),
+			UserID:     userID,
+		})
+	}
+
+	objects, err := nk.StorageRead(ctx, reads)
+	if err != nil {
+		return ratings, err
+	}
+	for _, object := range objects {
+		for i, userID := range userIDs {
+			if object.UserId != userID {
+				continue
+			}
+			if err := json.Unmarshal([]byte(object.Value), &ratings[i]); err != nil {
+				return ratings, err
+			}
+			versions[i] = object.Version
+		}
+	}
+
+	updated := [2]glicko2.Rating{
+		glicko2.Update(ratings[0], ratings[1], score),
+		glicko2.Update(ratings[1], ratings[0], 1-score),
+	}
+
+	writes := make([]*runtime.StorageWrite, 0, 2)
+	for i, userID := range userIDs {
+		if userID == aiUserId {
+			continue
+		}
+		value, err := json.Marshal(updated[i])
+		if err != nil {
+			return ratings, err
+		}
+		writes = append(writes, &runtime.StorageWrite{
+			Collection:      ratingCollection,
+			Key:             ratingKey(fast),
+			UserID:          userID,
+			Value:           string(value),
+			Version:         versions[i],
+			PermissionRead:  2, // readable by anyone
+			PermissionWrite: 0, // only the server can write
+		})
+	}
+	if _, err := nk.StorageWrite(ctx, writes); err != nil {
+		return ratings, err
+	}
+
+	return updated, nil
+}
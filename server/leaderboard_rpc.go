@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	nkapi "github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	defaultLeaderboardViewLimit = 20
+	maxLeaderboardViewLimit     = 100
+
+	// Friends are listed a page at a time, up to this many in all.
+	friendsPageSize = 100
+	maxFriends      = 1000
+
+	// Nakama's friend state for mutual friends.
+	friendStateMutual = 0
+)
+
+// Works out the records of one view of a leaderboard, and the caller's own record among them.
+type leaderboardViewFunc func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, userID string, request *api.RpcLeaderboardViewRequest, response *api.RpcLeaderboardViewResponse) error
+
+// An RPC returning a view of a leaderboard, along with the caller's own stats.
+func rpcLeaderboardView(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, view leaderboardViewFunc) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcLeaderboardViewRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+		if request.LeaderboardId == "" {
+			request.LeaderboardId = rankedNormalLeaderboardId
+		}
+		if _, ok := leaderboards[request.LeaderboardId]; !ok {
+			return "", errBadInput
+		}
+		if request.Limit <= 0 || request.Limit > maxLeaderboardViewLimit {
+			request.Limit = defaultLeaderboardViewLimit
+		}
+
+		response := &api.RpcLeaderboardViewResponse{LeaderboardId: request.LeaderboardId}
+		if err := view(ctx, logger, db, nk, userID, request, response); err != nil {
+			return "", err
+		}
+
+		stats, err := readPlayerStats(ctx, nk, userID)
+		if err != nil {
+			logger.Error("error reading stats for %s: %v", userID, err)
+			return "", errInternalError
+		}
+		response.OwnStats = stats.response(userID)
+
+		out, err := marshaler.Marshal(response)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(out), nil
+	}
+}
+
+// The records around the caller's own, ranked on the whole leaderboard.
+func rpcLeaderboardAroundMe(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return rpcLeaderboardView(marshaler, unmarshaler, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, userID string, request *api.RpcLeaderboardViewRequest, response *api.RpcLeaderboardViewResponse) error {
+		list, err := nk.LeaderboardRecordsHaystack(ctx, request.LeaderboardId, userID, int(request.Limit), "", 0)
+		if err != nil {
+			logger.Error("error listing leaderboard %s around %s: %v", request.LeaderboardId, userID, err)
+			return errInternalError
+		}
+
+		for _, record := range list.Records {
+			viewRecord := leaderboardViewRecord(record, record.Rank)
+			response.Records = append(response.Records, viewRecord)
+			if record.OwnerId == userID {
+				response.OwnRecord = viewRecord
+			}
+		}
+		return nil
+	})
+}
+
+// The caller and their friends, ranked among themselves.
+func rpcLeaderboardFriends(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return rpcLeaderboardView(marshaler, unmarshaler, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, userID string, request *api.RpcLeaderboardViewRequest, response *api.RpcLeaderboardViewResponse) error {
+		friendIDs, err := listFriendIDs(ctx, nk, userID)
+		if err != nil {
+			logger.Error("error listing friends of %s: %v", userID, err)
+			return errInternalError
+		}
+		ownerIDs := append([]string{userID}, friendIDs...)
+
+		_, records, _, _, err := nk.LeaderboardRecordsList(ctx, request.LeaderboardId, ownerIDs, 1, "", 0)
+		if err != nil {
+			logger.Error("error listing leaderboard %s records of friends: %v", request.LeaderboardId, err)
+			return errInternalError
+		}
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].Rank < records[j].Rank
+		})
+
+		for i, record := range records {
+			viewRecord := leaderboardViewRecord(record, int64(i+1))
+			if i < int(request.Limit) {
+				response.Records = append(response.Records, viewRecord)
+			}
+			if record.OwnerId == userID {
+				response.OwnRecord = viewRecord
+			}
+		}
+		return nil
+	})
+}
+
+// Players from one country, by default the caller's own, ranked among themselves. A player's country is the
+// "country" code in their account metadata.
+func rpcLeaderboardCountry(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return rpcLeaderboardView(marshaler, unmarshaler, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, userID string, request *api.RpcLeaderboardViewRequest, response *api.RpcLeaderboardViewResponse) error {
+		country := request.Country
+		if country == "" {
+			users, err := nk.UsersGetId(ctx, []string{userID}, nil)
+			if err != nil || len(users) == 0 {
+				logger.Error("error getting user %s: %v", userID, err)
+				return errInternalError
+			}
+			metadata := struct {
+				Country string `json:"country"`
+			}{}
+			if users[0].Metadata != "" {
+				if err := json.Unmarshal([]byte(users[0].Metadata), &metadata); err != nil {
+					logger.Warn("error decoding metadata of user %s: %v", userID, err)
+				}
+			}
+			country = metadata.Country
+		}
+		country = strings.ToUpper(country)
+		if country == "" {
+			return errNoCountry
+		}
+		response.Country = country
+
+		// Records for the current period of a leaderboard that resets expire when it next resets, the others never.
+		boards, err := nk.LeaderboardsGetId(ctx, []string{request.LeaderboardId})
+		if err != nil || len(boards) == 0 {
+			logger.Error("error getting leaderboard %s: %v", request.LeaderboardId, err)
+			return errInternalError
+		}
+		expiry := time.Unix(int64(boards[0].NextReset), 0).UTC()
+
+		// Every leaderboard sorts in descending order, ties going to whoever got there first.
+		query := `
+WITH ranked AS (
+    SELECT
+        lr.owner_id, lr.username, lr.score,
+        row_number() OVER (ORDER BY lr.score DESC, lr.subscore DESC, lr.update_time ASC) AS rank
+    FROM
+        leaderboard_record AS lr
+        JOIN users AS u ON u.id = lr.owner_id
+    WHERE
+        lr.leaderboard_id = $1
+        AND lr.expiry_time = $2
+        AND upper(u.metadata->>'country') = $3
+)
+SELECT owner_id, username, score, rank FROM ranked WHERE rank <= $4 OR owner_id = $5 ORDER BY rank;
+`
+		rows, err := db.QueryContext(ctx, query, request.LeaderboardId, expiry, country, request.Limit, userID)
+		if err != nil {
+			logger.WithField("err", err).Error("db.QueryContext country leaderboard error.")
+			return errInternalError
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var username sql.NullString
+			viewRecord := &api.LeaderboardViewRecord{}
+			if err := rows.Scan(&viewRecord.UserId, &username, &viewRecord.Score, &viewRecord.Rank); err != nil {
+				logger.WithField("err", err).Error("rows.Scan country leaderboard error.")
+				return errInternalError
+			}
+			viewRecord.Username = username.String
+
+			if viewRecord.Rank <= int64(request.Limit) {
+				response.Records = append(response.Records, viewRecord)
+			}
+			if viewRecord.UserId == userID {
+				response.OwnRecord = viewRecord
+			}
+		}
+		if err := rows.Err(); err != nil {
+			logger.WithField("err", err).Error("rows.Err country leaderboard error.")
+			return errInternalError
+		}
+		return nil
+	})
+}
+
+// The user IDs of a player's mutual friends, up to maxFriends of them.
+func listFriendIDs(ctx context.Context, nk runtime.NakamaModule, userID string) ([]string, error) {
+	var friendIDs []string
+	state := friendStateMutual
+	cursor := ""
+	for len(friendIDs) < maxFriends {
+		friends, next, err := nk.FriendsList(ctx, userID, friendsPageSize, &state, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, friend := range friends {
+			friendIDs = append(friendIDs, friend.User.Id)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	return friendIDs, nil
+}
+
+func leaderboardViewRecord(record *nkapi.LeaderboardRecord, rank int64) *api.LeaderboardViewRecord {
+	return &api.LeaderboardViewRecord{
+		UserId:   record.OwnerId,
+		Username: record.Username.GetValue(),
+		Score:    record.Score,
+		Rank:     rank,
+	}
+}
@@ -17,23 +17,54 @@ package main
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/bot"
+	"github.com/heroiclabs/nakama-project-template/solver"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
 var (
-	errInternalError  = runtime.NewError("internal server error", 13) // INTERNAL
-	errMarshal        = runtime.NewError("cannot marshal type", 13)   // INTERNAL
-	errNoInputAllowed = runtime.NewError("no input allowed", 3)       // INVALID_ARGUMENT
-	errNoUserIdFound  = runtime.NewError("no user ID in context", 3)  // INVALID_ARGUMENT
-	errUnmarshal      = runtime.NewError("cannot unmarshal type", 13) // INTERNAL
+	errAiBoardUnsupported = runtime.NewError("AI only plays on the classic board", 3) // INVALID_ARGUMENT
+	errBadInput           = runtime.NewError("input contained invalid data", 3)       // INVALID_ARGUMENT
+	errChallengeClosed    = runtime.NewError("challenge no longer open", 9)           // FAILED_PRECONDITION
+	errChallengeNotFound  = runtime.NewError("challenge not found", 5)                // NOT_FOUND
+	errInternalError      = runtime.NewError("internal server error", 13)             // INTERNAL
+	errMarshal            = runtime.NewError("cannot marshal type", 13)               // INTERNAL
+	errNoCountry          = runtime.NewError("no country set", 9)                     // FAILED_PRECONDITION
+	errNoInputAllowed     = runtime.NewError("no input allowed", 3)                   // INVALID_ARGUMENT
+	errNoSalt             = runtime.NewError("no salt set to anonymise with", 9)      // FAILED_PRECONDITION
+	errNoUserIdFound      = runtime.NewError("no user ID in context", 3)              // INVALID_ARGUMENT
+	errNotFriends         = runtime.NewError("can only challenge friends", 9)         // FAILED_PRECONDITION
+	errPrivateNotFound    = runtime.NewError("private match not found", 5)            // NOT_FOUND
+	errReplayNotFound     = runtime.NewError("replay not found", 5)                   // NOT_FOUND
+	errServerOnly         = runtime.NewError("only callable server to server", 7)     // PERMISSION_DENIED
+	errUnmarshal          = runtime.NewError("cannot unmarshal type", 13)             // INTERNAL
+	errUserNotFound       = runtime.NewError("user not found", 5)                     // NOT_FOUND
 )
 
-
 const (
-	rpcIdFindMatch = "find_match"
+	rpcIdFindMatch          = "find_match"
+	rpcIdListLiveMatches    = "list_live_matches"
+	rpcIdCreatePrivateMatch = "create_private_match"
+	rpcIdJoinPrivateMatch   = "join_private_match"
+	rpcIdChallengeFriend    = "challenge_friend"
+	rpcIdRespondChallenge   = "respond_challenge"
+	rpcIdGetActiveMatch     = "get_active_match"
+	rpcIdExportTrainingData = "export_training_data"
+	rpcIdListMatchHistory   = "list_match_history"
+	rpcIdGetReplay          = "get_replay"
+	rpcIdWatchReplay        = "watch_replay"
+	rpcIdExportGame         = "export_game"
+	rpcIdImportGame         = "import_game"
+	rpcIdGetPlayerStats     = "get_player_stats"
+	rpcIdListSeasonResults  = "list_season_results"
+	rpcIdLeaderboardAround  = "leaderboard_around_me"
+	rpcIdLeaderboardFriends = "leaderboard_friends"
+	rpcIdLeaderboardCountry = "leaderboard_country"
 )
 
 // noinspection GoUnusedExportedFunction
@@ -47,6 +78,79 @@ func InitModule(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runti
 		DiscardUnknown: false,
 	}
 
+	// AI_ENGINE picks how the AI moves unless a match asks otherwise, TF_SERVING_ADDRESS and
+	// TF_SERVING_GRPC_ADDRESS where the model is served, and AI_BATCH_WINDOW_MS how long moves asked of the model are
+	// gathered up to send together, and AI_CACHE_SIZE how many boards' predictions are remembered. TRAINING_DATA_SALT
+	// keeps anonymised user IDs in exported training data from being guessed, and has to be set to anonymise them.
+	// CHALLENGE_EXPIRY_SEC is how long a challenged friend has to join, and RECONNECT_GRACE_SEC how long a player who
+	// drops mid-round has to rejoin before they forfeit, 0 to forfeit them straight away. All are set in the runtime
+	// env section of the server config.
+	env, _ := ctx.Value(runtime.RUNTIME_CTX_ENV).(map[string]string)
+	tfServingAddress := env["TF_SERVING_ADDRESS"]
+	if tfServingAddress == "" {
+		tfServingAddress = "http://tf:8501/v1/models/ttt:predict"
+	}
+	tfServingGRPCAddress := env["TF_SERVING_GRPC_ADDRESS"]
+	if tfServingGRPCAddress == "" {
+		tfServingGRPCAddress = "tf:8500"
+	}
+	tfGRPC, err := bot.NewTFGRPC(tfServingGRPCAddress)
+	if err != nil {
+		return err
+	}
+	batchWindow := bot.DefaultBatchWindow
+	if windowMs, ok := env["AI_BATCH_WINDOW_MS"]; ok {
+		ms, err := strconv.Atoi(windowMs)
+		if err != nil || ms < 0 {
+			return fmt.Errorf("invalid AI_BATCH_WINDOW_MS %q", windowMs)
+		}
+		batchWindow = time.Duration(ms) * time.Millisecond
+	}
+	cacheSize := bot.DefaultCacheSize
+	if size, ok := env["AI_CACHE_SIZE"]; ok {
+		if cacheSize, err = strconv.Atoi(size); err != nil || cacheSize < 0 {
+			return fmt.Errorf("invalid AI_CACHE_SIZE %q", size)
+		}
+	}
+	// Every match shares one batcher and cache per model endpoint. Cached boards don't wait for a batch.
+	var tfProvider, tfGRPCProvider bot.Predictor = bot.NewTF(tfServingAddress), tfGRPC
+	if batchWindow > 0 {
+		tfProvider = bot.NewBatcher(tfProvider, batchWindow, bot.DefaultMaxBatch)
+		tfGRPCProvider = bot.NewBatcher(tfGRPCProvider, batchWindow, bot.DefaultMaxBatch)
+	}
+	if cacheSize > 0 {
+		tfProvider = bot.NewCache(tfProvider, nk, cacheSize)
+		tfGRPCProvider = bot.NewCache(tfGRPCProvider, nk, cacheSize)
+	}
+	aiSolver := bot.NewSolver(solver.New())
+	aiProviders := map[string]bot.MoveProvider{
+		bot.ProviderTF:       tfProvider,
+		bot.ProviderTFGRPC:   tfGRPCProvider,
+		bot.ProviderSolver:   aiSolver,
+		bot.ProviderScripted: bot.NewScripted(nil),
+	}
+	challengeExpiry := defaultChallengeExpiry
+	if expirySec, ok := env["CHALLENGE_EXPIRY_SEC"]; ok {
+		sec, err := strconv.Atoi(expirySec)
+		if err != nil || sec <= 0 {
+			return fmt.Errorf("invalid CHALLENGE_EXPIRY_SEC %q", expirySec)
+		}
+		challengeExpiry = time.Duration(sec) * time.Second
+	}
+	reconnectGraceSec := defaultReconnectGraceSec
+	if graceSec, ok := env["RECONNECT_GRACE_SEC"]; ok {
+		if reconnectGraceSec, err = strconv.Atoi(graceSec); err != nil || reconnectGraceSec < 0 {
+			return fmt.Errorf("invalid RECONNECT_GRACE_SEC %q", graceSec)
+		}
+	}
+	aiProvider := env["AI_ENGINE"]
+	if aiProvider == "" {
+		aiProvider = bot.ProviderTF
+	}
+	if _, ok := aiProviders[aiProvider]; !ok {
+		return fmt.Errorf("unknown AI_ENGINE %q", aiProvider)
+	}
+
 	if err := InitLeaderboard(ctx, nk, logger); err != nil {
 		return err
 	}
@@ -55,11 +159,106 @@ func InitModule(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runti
 		return err
 	}
 
+	if err := initializer.RegisterRpc(rpcIdListLiveMatches, rpcListLiveMatches(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdCreatePrivateMatch, rpcCreatePrivateMatch(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdJoinPrivateMatch, rpcJoinPrivateMatch(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdChallengeFriend, rpcChallengeFriend(marshaler, unmarshaler, challengeExpiry)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdRespondChallenge, rpcRespondChallenge(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdGetActiveMatch, rpcGetActiveMatch(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdListMatchHistory, rpcListMatchHistory(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdGetReplay, rpcGetReplay(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdWatchReplay, rpcWatchReplay(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdExportGame, rpcExportGame(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdImportGame, rpcImportGame(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdGetPlayerStats, rpcGetPlayerStats(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdListSeasonResults, rpcListSeasonResults(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdLeaderboardAround, rpcLeaderboardAroundMe(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdLeaderboardFriends, rpcLeaderboardFriends(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdLeaderboardCountry, rpcLeaderboardCountry(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdExportTrainingData, rpcExportTrainingData(marshaler, unmarshaler, env["TRAINING_DATA_SALT"])); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterBeforeRt("MatchmakerAdd", beforeMatchmakerAdd); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterMatchmakerOverride(matchmakerOverride); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterLeaderboardReset(leaderboardReset); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterMatchmakerMatched(matchmakerMatched); err != nil {
+		return err
+	}
+
 	if err := initializer.RegisterMatch(moduleName, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error) {
 		return &MatchHandler{
-			marshaler:        marshaler,
-			unmarshaler:      unmarshaler,
-			tfServingAddress: "http://tf:8501/v1/models/ttt:predict",
+			marshaler:         marshaler,
+			unmarshaler:       unmarshaler,
+			aiProviders:       aiProviders,
+			defaultAiProvider: aiProvider,
+			solver:            aiSolver,
+			reconnectGraceSec: reconnectGraceSec,
+		}, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterMatch(replayModuleName, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error) {
+		return &ReplayMatchHandler{
+			marshaler: marshaler,
 		}, nil
 	}); err != nil {
 		return err
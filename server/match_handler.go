@@ -19,6 +19,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"math/rand"
+	"strconv"
 	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
@@ -26,6 +27,8 @@ import (
 
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/bot"
+	"github.com/heroiclabs/nakama-project-template/game"
 )
 
 const (
@@ -35,34 +38,45 @@ const (
 
 	maxEmptySec = 30
 
-	delayBetweenGamesSec = 10
-	turnTimeFastSec      = 16
-	turnTimeNormalSec    = 10
-)
+	maxSpectators = 50
 
-var winningPositions = [][]int32{
-	{0, 1, 2},
-	{3, 4, 5},
-	{6, 7, 8},
-	{0, 3, 6},
-	{1, 4, 7},
-	{2, 5, 8},
-	{0, 4, 8},
-	{2, 4, 6},
-}
+	delayBetweenRoundsSec = 5
+	rematchTimeoutSec     = 20
+	turnTimeFastSec       = 16
+	turnTimeNormalSec     = 10
+
+	// How long a player who loses their connection mid-round has to rejoin, unless RECONNECT_GRACE_SEC says
+	// otherwise.
+	defaultReconnectGraceSec = 20
+)
 
 // Compile-time check to make sure all required functions are implemented.
 var _ runtime.Match = &MatchHandler{}
 
 type MatchLabel struct {
-	Open int `json:"open"`
-	Fast int `json:"fast"`
+	Open       int `json:"open"`
+	Fast       int `json:"fast"`
+	Width      int `json:"width"`
+	Height     int `json:"height"`
+	WinLength  int `json:"win_length"`
+	Spectators int `json:"spectators"`
+	Series     int `json:"series_length"`
+	Ranked     int `json:"ranked"`
+	Ai         int `json:"ai"`
+	Private    int `json:"private"`
 }
 
 type MatchHandler struct {
-	marshaler        *protojson.MarshalOptions
-	unmarshaler      *protojson.UnmarshalOptions
-	tfServingAddress string
+	marshaler   *protojson.MarshalOptions
+	unmarshaler *protojson.UnmarshalOptions
+	// Every way the AI can pick its moves, by name, and the one used unless a match asks for another.
+	aiProviders       map[string]bot.MoveProvider
+	defaultAiProvider string
+	// Shared by all matches, so positions solved in one game are remembered for the rest.
+	solver *bot.Solver
+	// How long a player who loses their connection mid-round has to rejoin before they forfeit. With none they
+	// forfeit straight away.
+	reconnectGraceSec int
 }
 
 type MatchState struct {
@@ -70,29 +84,114 @@ type MatchState struct {
 	label      *MatchLabel
 	emptyTicks int
 	ai         bool
-	messages   chan runtime.MatchData
+	// How well the AI plays, if there is one.
+	difficulty api.Difficulty
+	// What picks the AI's moves.
+	aiProvider bot.MoveProvider
+	// The AI's latest move request, and whether it is still being worked out. Only a move answering it is played.
+	aiRequestID int64
+	aiPending   bool
+	aiCancel    context.CancelFunc
+	messages    chan runtime.MatchData
+	// Board dimensions and win length used for every game in this match.
+	config game.Config
+	// Number of rounds in a series, the first player to win a majority of them wins the series.
+	seriesLength int
 
 	// Currently connected users, or reserved spaces.
 	presences map[string]runtime.Presence
 	// Number of users currently in the process of connecting to the match.
 	joinsInProgress int
+	// User IDs allowed to take the player slots, if the match was set up for specific players. Nil if anyone may join.
+	reserved map[string]bool
+	// The room code everyone joining must present, if the match is private.
+	code string
+	// True if the match was created for a challenge between two friends, who are the only ones let in.
+	challenge bool
+	// Ticks left for both players to join a challenge match, until they have.
+	challengeRemainingTicks int64
+	// Set when the challenged friend declines, to close the match.
+	challengeDeclined bool
+	// Read-only presences watching the match, or reserved spaces for spectators still connecting.
+	// They receive every broadcast but never take one of the two player slots.
+	spectators map[string]runtime.Presence
 
 	// True if there's a game currently in progress.
 	playing bool
-	// Current state of the board.
-	board []api.Mark
+	// The current or most recently completed game, holding the board and turn order.
+	game *game.Game
 	// Mark assignments to player user IDs.
 	marks map[string]api.Mark
-	// Whose turn it currently is.
-	mark api.Mark
 	// Ticks until they must submit their move.
 	deadlineRemainingTicks int64
-	// The winner of the current game.
-	winner api.Mark
-	// The winner positions.
-	winnerPositions []int32
-	// Ticks until the next game starts, if applicable.
-	nextGameRemainingTicks int64
+	// Ticks left for players who lost their connection mid-round to rejoin, by user ID.
+	disconnectRemainingTicks map[string]int64
+	// When the current round started, and the moves played in it so far.
+	roundStartedAt time.Time
+	moves          []gameMove
+	// The round of the current series, starting at 1.
+	roundNumber int32
+	// Rounds won in the current series, by user ID.
+	seriesScore map[string]int32
+	// Ticks until the next round of the series starts, if applicable.
+	nextRoundRemainingTicks int64
+	// User IDs of the players who have agreed to play another round.
+	rematchVotes map[string]bool
+	// Ticks until the players must agree to a rematch, if a round has completed.
+	rematchRemainingTicks int64
+}
+
+func (ms *MatchState) SpectatorCount() int {
+	count := 0
+	for _, p := range ms.spectators {
+		if p != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Check whether the current series has been decided. It has once a player has won a majority of the rounds, or when
+// all the rounds have been played, since drawn rounds don't count towards either player. The winner is empty if
+// the series ends level.
+func (ms *MatchState) SeriesResult() (bool, string) {
+	var leader string
+	var leaderScore, runnerUpScore int32
+	for userID, score := range ms.seriesScore {
+		if score > leaderScore {
+			leader, leaderScore, runnerUpScore = userID, score, leaderScore
+		} else if score > runnerUpScore {
+			runnerUpScore = score
+		}
+	}
+
+	if leaderScore > int32(ms.seriesLength/2) {
+		return true, leader
+	}
+	if ms.roundNumber >= int32(ms.seriesLength) {
+		if leaderScore == runnerUpScore {
+			return true, ""
+		}
+		return true, leader
+	}
+	return false, ""
+}
+
+// Public matches advertise themselves as open while they have a free player slot. Matches reserved for specific
+// players or joined by room code never do.
+func (ms *MatchState) Public() bool {
+	return ms.reserved == nil && ms.code == ""
+}
+
+// Check whether the player with a mark has lost their connection mid-round and may still rejoin. Their turn clock is
+// paused meanwhile.
+func (ms *MatchState) Reconnecting(mark api.Mark) bool {
+	for userID := range ms.disconnectRemainingTicks {
+		if ms.marks[userID] == mark {
+			return true
+		}
+	}
+	return false
 }
 
 func (ms *MatchState) ConnectedCount() int {
@@ -113,13 +212,88 @@ func (m *MatchHandler) MatchInit(ctx context.Context, logger runtime.Logger, db
 	}
 
 	ai, _ := params["ai"].(bool)
+	ranked, _ := params["ranked"].(bool)
+
+	difficulty := api.Difficulty(intParam(params, "difficulty", int(api.Difficulty_DIFFICULTY_HARD)))
+	if _, ok := api.Difficulty_name[int32(difficulty)]; !ok || difficulty == api.Difficulty_DIFFICULTY_UNSPECIFIED {
+		logger.Error("invalid match init parameter \"difficulty\"")
+		return nil, 0, ""
+	}
+
+	aiProviderName, _ := params["ai_provider"].(string)
+	if aiProviderName == "" {
+		aiProviderName = m.defaultAiProvider
+	}
+	aiProvider, ok := m.aiProviders[aiProviderName]
+	if !ok {
+		logger.Error("invalid match init parameter \"ai_provider\"")
+		return nil, 0, ""
+	}
+	if script := int32sParam(params, "ai_script"); aiProviderName == bot.ProviderScripted && script != nil {
+		// Test matches can give the scripted bot its moves.
+		aiProvider = bot.NewScripted(script)
+	}
+
+	config := game.Config{
+		Width:     intParam(params, "width", game.DefaultWidth),
+		Height:    intParam(params, "height", game.DefaultHeight),
+		WinLength: intParam(params, "win_length", game.DefaultWinLength),
+	}
+	if err := config.Validate(); err != nil {
+		logger.Error("invalid match init board parameters: %v", err)
+		return nil, 0, ""
+	}
+
+	seriesLength := intParam(params, "series_length", 1)
+	if !validSeriesLength(seriesLength) {
+		logger.Error("invalid match init parameter \"series_length\"")
+		return nil, 0, ""
+	}
+
+	// Private matches are only joined with their room code.
+	code, _ := params["code"].(string)
+
+	// Hold the player slots for the users the match was created for, if any.
+	var reserved map[string]bool
+	if userIDs := stringsParam(params, "user_ids"); len(userIDs) > 0 {
+		reserved = make(map[string]bool, len(userIDs))
+		for _, userID := range userIDs {
+			reserved[userID] = true
+		}
+	}
+
+	// Challenge matches close if the players haven't both joined within the expiry.
+	challengeExpirySec := intParam(params, "challenge_expiry_sec", 0)
+	if challengeExpirySec > 0 && reserved == nil {
+		logger.Error("invalid match init parameter \"challenge_expiry_sec\", challenges need \"user_ids\"")
+		return nil, 0, ""
+	}
 
 	label := &MatchLabel{
-		Open: 1,
+		Open:      1,
+		Width:     config.Width,
+		Height:    config.Height,
+		WinLength: config.WinLength,
+		Series:    seriesLength,
 	}
 	if fast {
 		label.Fast = 1
 	}
+	if ranked {
+		label.Ranked = 1
+	}
+	if ai {
+		label.Ai = 1
+	}
+	if reserved != nil {
+		// Keep it out of public listings, nobody else can join anyway.
+		label.Open = 0
+	}
+	if code != "" {
+		// Keep it out of public listings and the list of live matches.
+		label.Open = 0
+		label.Private = 1
+	}
 	labelJSON, err := json.Marshal(label)
 	if err != nil {
 		logger.WithField("error", err).Error("match init failed")
@@ -127,11 +301,23 @@ func (m *MatchHandler) MatchInit(ctx context.Context, logger runtime.Logger, db
 	}
 
 	state := &MatchState{
-		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
-		label:     label,
-		ai:        ai,
-		presences: make(map[string]runtime.Presence, 2),
-		messages:  make(chan runtime.MatchData, 1),
+		random:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		label:        label,
+		ai:           ai,
+		difficulty:   difficulty,
+		aiProvider:   aiProvider,
+		config:       config,
+		seriesLength: seriesLength,
+		reserved:     reserved,
+		code:         code,
+		presences:    make(map[string]runtime.Presence, 2),
+		spectators:   make(map[string]runtime.Presence),
+		messages:     make(chan runtime.MatchData, 1),
+
+		disconnectRemainingTicks: make(map[string]int64, 2),
+
+		challenge:               challengeExpirySec > 0,
+		challengeRemainingTicks: int64(challengeExpirySec) * tickRate,
 	}
 
 	// Automatically add AI player
@@ -157,6 +343,33 @@ func (m *MatchHandler) MatchJoinAttempt(ctx context.Context, logger runtime.Logg
 		}
 	}
 
+	// Check if the match is private, and they have its room code. Spectators need it too.
+	if s.code != "" && normaliseRoomCode(metadata["code"]) != s.code {
+		return s, false, "wrong room code"
+	}
+
+	// Check if it's a user asking to watch the match rather than play in it.
+	if spectate, _ := strconv.ParseBool(metadata["spectate"]); spectate {
+		if s.challenge {
+			// Only the two friends get into a challenge.
+			return s, false, "match reserved"
+		}
+		if presence, ok := s.spectators[presence.GetUserId()]; ok && presence != nil {
+			return s, false, "already joined"
+		}
+		if len(s.spectators) >= maxSpectators {
+			return s, false, "too many spectators"
+		}
+
+		s.spectators[presence.GetUserId()] = nil
+		return s, true, ""
+	}
+
+	// Check if the player slots are held for someone else.
+	if s.reserved != nil && !s.reserved[presence.GetUserId()] {
+		return s, false, "match reserved"
+	}
+
 	// Check if match is full.
 	if s.ConnectedCount()+s.joinsInProgress >= 2 {
 		return s, false, "match full"
@@ -172,10 +385,28 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 	t := time.Now().UTC()
 
 	for _, presence := range presences {
+		if _, ok := s.spectators[presence.GetUserId()]; ok {
+			s.spectators[presence.GetUserId()] = presence
+			m.sendSpectatorState(logger, dispatcher, s, presence, t)
+			continue
+		}
+
 		s.emptyTicks = 0
 		s.presences[presence.GetUserId()] = presence
 		s.joinsInProgress--
 
+		if _, ok := s.disconnectRemainingTicks[presence.GetUserId()]; ok {
+			// Back within the reconnect window, let the opponent know the round carries on.
+			delete(s.disconnectRemainingTicks, presence.GetUserId())
+			var opponents []runtime.Presence
+			for _, player := range playerPresences(s) {
+				if player.GetUserId() != presence.GetUserId() {
+					opponents = append(opponents, player)
+				}
+			}
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_OPPONENT_RECONNECTED), nil, opponents, nil, true)
+		}
+
 		// Check if we must send a message to this user to update them on the current game state.
 		var opCode api.OpCode
 		var msg proto.Message
@@ -183,20 +414,15 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 			// There's a game still currently in progress, the player is re-joining after a disconnect. Give them a state update.
 			opCode = api.OpCode_OPCODE_UPDATE
 			msg = &api.Update{
-				Board:    s.board,
-				Mark:     s.mark,
+				Board:    s.game.Board(),
+				Mark:     s.game.Mark(),
 				Deadline: t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
 			}
-		} else if s.board != nil && s.marks != nil && s.marks[presence.GetUserId()] > api.Mark_MARK_UNSPECIFIED {
+		} else if s.game != nil && s.marks != nil && s.marks[presence.GetUserId()] > api.Mark_MARK_UNSPECIFIED {
 			// There's no game in progress but we still have a completed game that the user was part of.
 			// They likely disconnected before the game ended, and have since forfeited because they took too long to return.
 			opCode = api.OpCode_OPCODE_DONE
-			msg = &api.Done{
-				Board:           s.board,
-				Winner:          s.winner,
-				WinnerPositions: s.winnerPositions,
-				NextGameStart:   t.Add(time.Duration(s.nextGameRemainingTicks/tickRate) * time.Second).Unix(),
-			}
+			msg = doneMessage(s, t)
 		}
 
 		// Send a message to the user that just joined, if one is needed based on the logic above.
@@ -211,15 +437,17 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 	}
 
 	// Check if match was open to new players, but should now be closed.
+	labelChanged := false
 	if len(s.presences) >= 2 && s.label.Open != 0 {
 		s.label.Open = 0
-		if labelJSON, err := json.Marshal(s.label); err != nil {
-			logger.Error("error encoding label: %v", err)
-		} else {
-			if err := dispatcher.MatchLabelUpdate(string(labelJSON)); err != nil {
-				logger.Error("error updating label: %v", err)
-			}
-		}
+		labelChanged = true
+	}
+	if spectators := s.SpectatorCount(); s.label.Spectators != spectators {
+		s.label.Spectators = spectators
+		labelChanged = true
+	}
+	if labelChanged {
+		updateLabel(logger, dispatcher, s.label)
 	}
 
 	return s
@@ -227,8 +455,24 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 
 func (m *MatchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
 	s := state.(*MatchState)
+	spectatorLeft := false
+	var playersLeft []string
 	for _, presence := range presences {
+		if _, ok := s.spectators[presence.GetUserId()]; ok {
+			delete(s.spectators, presence.GetUserId())
+			spectatorLeft = true
+			continue
+		}
 		s.presences[presence.GetUserId()] = nil
+		playersLeft = append(playersLeft, presence.GetUserId())
+	}
+
+	if spectatorLeft {
+		s.label.Spectators = s.SpectatorCount()
+		updateLabel(logger, dispatcher, s.label)
+	}
+	if len(playersLeft) == 0 {
+		return s
 	}
 
 	var humanPlayersRemaining []runtime.Presence
@@ -238,16 +482,39 @@ func (m *MatchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, db
 		}
 	}
 
+	// Mid-round, hold the round open for the opponent to rejoin. They forfeit if they don't in time.
+	if len(humanPlayersRemaining) == 1 && s.playing && m.reconnectGraceSec > 0 {
+		deadline := time.Now().UTC().Add(time.Duration(m.reconnectGraceSec) * time.Second).Unix()
+		for _, userID := range playersLeft {
+			s.disconnectRemainingTicks[userID] = int64(m.reconnectGraceSec) * tickRate
+
+			buf, err := m.marshaler.Marshal(&api.OpponentDisconnected{UserId: userID, Deadline: deadline})
+			if err != nil {
+				logger.Error("error encoding message: %v", err)
+				continue
+			}
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_OPPONENT_DISCONNECTED), buf,
+				humanPlayersRemaining, nil, true)
+		}
+		return s
+	}
+
 	// Notify remaining player that the opponent has left the game
 	if len(humanPlayersRemaining) == 1 {
 		_ = dispatcher.BroadcastMessage(
 			int64(api.OpCode_OPCODE_OPPONENT_LEFT), nil,
 			humanPlayersRemaining, nil, true)
-		s.playing = false
-		s.winner = s.marks[humanPlayersRemaining[0].GetUserId()]
+		if s.playing {
+			s.game.Disconnect(game.Opponent(s.marks[humanPlayersRemaining[0].GetUserId()]))
+			endRound(ctx, nk, logger, s)
+		}
 	} else if s.ai && len(humanPlayersRemaining) == 0 {
 		delete(s.presences, aiUserId)
 		s.ai = false
+		cancelAiTurn(s)
+		// Whoever joins next plays a human game.
+		s.label.Ai = 0
+		updateLabel(logger, dispatcher, s.label)
 	}
 
 	return s
@@ -256,7 +523,8 @@ func (m *MatchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, db
 func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
 	s := state.(*MatchState)
 
-	if s.ConnectedCount()+s.joinsInProgress == 0 {
+	// A challenge match waits for the players until the challenge expires, even while it's empty.
+	if s.ConnectedCount()+s.joinsInProgress == 0 && s.challengeRemainingTicks == 0 {
 		s.emptyTicks++
 		if s.emptyTicks >= maxEmptySec*tickRate {
 			// Match has been empty for too long, close it.
@@ -265,6 +533,22 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 		}
 	}
 
+	if s.challengeDeclined {
+		logger.Info("closing match, challenge declined")
+		return nil
+	}
+	if s.challengeRemainingTicks > 0 {
+		if s.ConnectedCount() >= 2 {
+			// Both players made it, the challenge is on.
+			s.challengeRemainingTicks = 0
+		} else if s.challengeRemainingTicks--; s.challengeRemainingTicks <= 0 {
+			matchID, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
+			expireChallenge(ctx, nk, logger, matchID)
+			logger.Info("closing match, challenge expired")
+			return nil
+		}
+	}
+
 	t := time.Now().UTC()
 
 	// If there's no game in progress check if we can (and should) start one!
@@ -277,36 +561,49 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 		}
 
 		// Check if we need to update the label so the match now advertises itself as open to join.
-		if len(s.presences) < 2 && s.label.Open != 1 {
+		// Matches reserved for specific players or private matches never do.
+		if len(s.presences) < 2 && s.label.Open != 1 && s.Public() {
 			s.label.Open = 1
-			if labelJSON, err := json.Marshal(s.label); err != nil {
-				logger.Error("error encoding label: %v", err)
-			} else {
-				if err := dispatcher.MatchLabelUpdate(string(labelJSON)); err != nil {
-					logger.Error("error updating label: %v", err)
-				}
-			}
+			updateLabel(logger, dispatcher, s.label)
 		}
 
 		// Check if we have enough players to start a game.
 		if len(s.presences) < 2 {
+			if s.game != nil {
+				// The opponent left after the last round, so there's nobody to continue the series or have a rematch
+				// with. The next player to join starts afresh.
+				resetSeries(s)
+			}
 			return s
 		}
 
-		// Check if enough time has passed since the last game.
-		if s.nextGameRemainingTicks > 0 {
-			s.nextGameRemainingTicks--
-			return s
+		if s.game != nil {
+			if decided, _ := s.SeriesResult(); decided {
+				// Once a series has been decided, only start another when both players have agreed to a rematch.
+				if !m.rematchAgreed(logger, dispatcher, s, messages, t) {
+					return s
+				}
+				s.roundNumber = 0
+				s.seriesScore = nil
+			} else if s.nextRoundRemainingTicks > 0 {
+				// Check if enough time has passed since the last round of the series.
+				s.nextRoundRemainingTicks--
+				return s
+			}
 		}
 
 		// We can start a game! Set up the game state and assign the marks to each player.
+		// Players who have just played each other swap marks, so X (who always moves first) alternates.
+		previousMarks := s.marks
 		s.playing = true
-		s.board = make([]api.Mark, 9)
+		s.game = game.New(s.config)
 		s.marks = make(map[string]api.Mark, 2)
 		marks := []api.Mark{api.Mark_MARK_X, api.Mark_MARK_O}
 
 		for userID := range s.presences {
-			if s.ai {
+			if previousMark, ok := previousMarks[userID]; ok {
+				s.marks[userID] = game.Opponent(previousMark)
+			} else if s.ai {
 				if userID == aiUserId {
 					s.marks[userID] = api.Mark_MARK_O
 				} else {
@@ -317,19 +614,23 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 				marks = marks[1:]
 			}
 		}
-		s.mark = api.Mark_MARK_X
-		s.winner = api.Mark_MARK_UNSPECIFIED
-		s.winnerPositions = nil
+		if s.seriesScore == nil {
+			s.seriesScore = make(map[string]int32, 2)
+			for userID := range s.marks {
+				s.seriesScore[userID] = 0
+			}
+		}
+		s.roundNumber++
+		s.roundStartedAt = t
+		s.moves = nil
 		s.deadlineRemainingTicks = calculateDeadlineTicks(s.label)
-		s.nextGameRemainingTicks = 0
+		s.nextRoundRemainingTicks = 0
+		s.rematchVotes = nil
+		s.rematchRemainingTicks = 0
+		setActiveMatch(ctx, nk, logger, s)
 
 		// Notify the players a new game has started.
-		buf, err := m.marshaler.Marshal(&api.Start{
-			Board:    s.board,
-			Marks:    s.marks,
-			Mark:     s.mark,
-			Deadline: t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
-		})
+		buf, err := m.marshaler.Marshal(startMessage(s, t))
 		if err != nil {
 			logger.Error("error encoding message: %v", err)
 		} else {
@@ -339,26 +640,38 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 	}
 
 	// Append AI moves, if any
-	select {
-	case msg := <-s.messages:
-		messages = append(messages, msg)
-	default:
+drain:
+	for {
+		select {
+		case msg := <-s.messages:
+			messages = append(messages, msg)
+		default:
+			break drain
+		}
 	}
 
 	// There's a game in progress. Check for input, update match state, and send messages to clients.
 
 	for _, message := range messages {
+		if aiMessage, ok := message.(*aiMatchData); ok {
+			if !s.aiPending || aiMessage.requestID != s.aiRequestID {
+				// Worked out for a board that has since changed.
+				logger.Debug("discarding stale AI move")
+				continue
+			}
+			cancelAiTurn(s)
+		}
+
+		if spectator, ok := s.spectators[message.GetUserId()]; ok {
+			// Spectators are read-only, whatever they send is rejected.
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{spectator}, nil, true)
+			continue
+		}
+
 		p := s.presences[message.GetUserId()]
 
 		switch api.OpCode(message.GetOpCode()) {
 		case api.OpCode_OPCODE_MOVE:
-			mark := s.marks[message.GetUserId()]
-			if s.mark != mark {
-				// It is not this player's turn.
-				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{p}, nil, true)
-				continue
-			}
-
 			msg := &api.Move{}
 			err := m.unmarshaler.Unmarshal(message.GetData(), msg)
 			if err != nil {
@@ -366,92 +679,19 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{p}, nil, true)
 				continue
 			}
-			if msg.Position < 0 || msg.Position > 8 || s.board[msg.Position] != api.Mark_MARK_UNSPECIFIED {
-				// Client sent a position outside the board, or one that has already been played.
+
+			// Update the game state. The engine refuses moves out of turn, outside the board, or on a position
+			// that has already been played.
+			mark := s.marks[message.GetUserId()]
+			if err := s.game.ApplyMove(mark, msg.Position); err != nil {
 				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{p}, nil, true)
 				continue
 			}
-
-			// Update the game state.
-			s.board[msg.Position] = mark
-			switch mark {
-			case api.Mark_MARK_X:
-				s.mark = api.Mark_MARK_O
-			case api.Mark_MARK_O:
-				s.mark = api.Mark_MARK_X
-			}
+			s.moves = append(s.moves, gameMove{Position: msg.Position, Mark: mark, Time: t.UnixMilli()})
 			s.deadlineRemainingTicks = calculateDeadlineTicks(s.label)
 
-		winCheck:
-			for _, winningPosition := range winningPositions {
-				allMatch := true
-				for _, pos := range winningPosition {
-					if s.board[pos] != mark {
-						allMatch = false
-						break
-					}
-				}
-				if allMatch {
-					// Found winner
-					s.winner = mark
-					s.winnerPositions = winningPosition
-					s.playing = false
-					s.deadlineRemainingTicks = 0
-					s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
-
-					var winnerUserID, loserUserID string
-
-					for userID, mark := range s.marks {
-						if mark == s.winner {
-							winnerUserID = userID
-						} else {
-							loserUserID = userID
-						}
-					}
-
-					if winnerUserID != "" {
-						// Update storage for winner (+1 win)
-						_, err := updatePlayerStats(ctx, nk, logger, winnerUserID, 1, 0, 1)
-						if err != nil {
-							logger.Error("failed updating winner stats: %v", err)
-						}
-
-						// Update storage for loser (+1 loss)
-						if loserUserID != "" {
-							_, err := updatePlayerStats(ctx, nk, logger, loserUserID, 0, 1, 1)
-							if err != nil {
-								logger.Error("failed updating loser stats: %v", err)
-							}
-						}
-					}
-
-					break winCheck
-				}
-			}
-
-			// --- Check for a tie ---
-			if s.winner == api.Mark_MARK_UNSPECIFIED {
-				isTie := true
-				for _, m := range s.board {
-					if m == api.Mark_MARK_UNSPECIFIED {
-						isTie = false
-						break
-					}
-				}
-				if isTie {
-					s.playing = false
-					s.deadlineRemainingTicks = 0
-					s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
-
-					// Update stats for both players on tie
-					for userID := range s.marks {
-						_, err := updatePlayerStats(ctx, nk, logger, userID, 0, 0, 1)
-						if err != nil {
-							logger.Error("failed updating tie stats for user %s: %v", userID, err)
-							continue
-						}
-					}
-				}
+			if s.game.Outcome().Done {
+				endRound(ctx, nk, logger, s)
 			}
 
 			var opCode api.OpCode
@@ -459,45 +699,13 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 			if s.playing {
 				opCode = api.OpCode_OPCODE_UPDATE
 				outgoingMsg = &api.Update{
-					Board:    s.board,
-					Mark:     s.mark,
+					Board:    s.game.Board(),
+					Mark:     s.game.Mark(),
 					Deadline: t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
 				}
 			} else {
 				opCode = api.OpCode_OPCODE_DONE
-				outgoingMsg = &api.Done{
-					Board:           s.board,
-					Winner:          s.winner,
-					WinnerPositions: s.winnerPositions,
-					NextGameStart:   t.Add(time.Duration(s.nextGameRemainingTicks/tickRate) * time.Second).Unix(),
-				}
-
-				var winnerUserID, loserUserID string
-
-				// Identify winner and loser
-				for userID, mark := range s.marks {
-					if mark == s.winner {
-						winnerUserID = userID
-					} else {
-						loserUserID = userID
-					}
-				}
-
-				if winnerUserID != "" {
-					// Update storage for winner (+1 win, +1 total)
-					_, err := updatePlayerStats(ctx, nk, logger, winnerUserID, 1, 0, 1)
-					if err != nil {
-						logger.Error("failed updating winner stats: %v", err)
-					}
-
-					// Update storage for loser (+1 loss, +1 total)
-					if loserUserID != "" {
-						_, err := updatePlayerStats(ctx, nk, logger, loserUserID, 0, 1, 1)
-						if err != nil {
-							logger.Error("failed updating loser stats: %v", err)
-						}
-					}
-				}
+				outgoingMsg = doneMessage(s, t)
 			}
 
 			buf, err := m.marshaler.Marshal(outgoingMsg)
@@ -511,6 +719,17 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 				logger.Error("AI player is already playing")
 				continue
 			}
+			if s.config != game.DefaultConfig() {
+				// The AI only plays on the classic board, as with find_match.
+				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{p}, nil, true)
+				continue
+			}
+			if len(s.disconnectRemainingTicks) > 0 {
+				// The opponent may still come back, the AI can't take their place until their reconnect window has
+				// run out.
+				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{p}, nil, true)
+				continue
+			}
 
 			var activePlayers []runtime.Presence
 			for userId, presence := range s.presences {
@@ -529,6 +748,11 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 
 			s.ai = true
 			s.presences[aiUserId] = aiPresenceObj
+			// The result goes on the AI leaderboard now.
+			s.label.Ai = 1
+			updateLabel(logger, dispatcher, s.label)
+			// Like any AI match, there's no round to return to once the player has left.
+			clearActiveMatch(ctx, nk, logger, s)
 
 			if s.marks[activePlayers[0].GetUserId()] == api.Mark_MARK_O {
 				s.marks[aiUserId] = api.Mark_MARK_X
@@ -544,26 +768,37 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 		}
 	}
 
-	// Keep track of the time remaining for the player to submit their move. Idle players forfeit.
-	if s.playing {
+	// Players who lost their connection forfeit once their reconnect window runs out.
+	for userID := range s.disconnectRemainingTicks {
+		if !s.playing {
+			break
+		}
+		if s.disconnectRemainingTicks[userID]--; s.disconnectRemainingTicks[userID] > 0 {
+			continue
+		}
+		delete(s.disconnectRemainingTicks, userID)
+		s.game.Disconnect(s.marks[userID])
+		endRound(ctx, nk, logger, s)
+
+		_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_OPPONENT_LEFT), nil, playerPresences(s), nil, true)
+		buf, err := m.marshaler.Marshal(doneMessage(s, t))
+		if err != nil {
+			logger.Error("error encoding message: %v", err)
+		} else {
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_DONE), buf, nil, nil, true)
+		}
+	}
+
+	// Keep track of the time remaining for the player to submit their move. Idle players forfeit, but the clock
+	// doesn't run while the player to move is away.
+	if s.playing && !s.Reconnecting(s.game.Mark()) {
 		s.deadlineRemainingTicks--
 		if s.deadlineRemainingTicks <= 0 {
 			// The player has run out of time to submit their move.
-			s.playing = false
-			switch s.mark {
-			case api.Mark_MARK_X:
-				s.winner = api.Mark_MARK_O
-			case api.Mark_MARK_O:
-				s.winner = api.Mark_MARK_X
-			}
-			s.deadlineRemainingTicks = 0
-			s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
-
-			buf, err := m.marshaler.Marshal(&api.Done{
-				Board:         s.board,
-				Winner:        s.winner,
-				NextGameStart: t.Add(time.Duration(s.nextGameRemainingTicks/tickRate) * time.Second).Unix(),
-			})
+			s.game.Forfeit(s.game.Mark())
+			endRound(ctx, nk, logger, s)
+
+			buf, err := m.marshaler.Marshal(doneMessage(s, t))
 			if err != nil {
 				logger.Error("error encoding message: %v", err)
 			} else {
@@ -573,9 +808,16 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 	}
 
 	// The next turn is AI's
-	if s.ai && s.mark == s.marks[aiUserId] {
-		if err := m.aiTurn(s); err != nil {
-			logger.Error("error making AI turn: %v", err)
+	if s.ai && s.playing && s.game.Mark() == s.marks[aiUserId] {
+		switch {
+		case !s.aiPending:
+			m.startAiTurn(ctx, logger, s)
+		case s.deadlineRemainingTicks <= aiFallbackSec*tickRate:
+			// The AI hasn't answered in time.
+			moves := s.game.LegalMoves()
+			if err := m.queueAiMove(s.messages, s.aiRequestID, moves[s.random.Intn(len(moves))]); err != nil {
+				logger.Error("error making AI turn: %v", err)
+			}
 		}
 	}
 
@@ -583,13 +825,312 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 }
 
 func (m *MatchHandler) MatchSignal(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, data string) (interface{}, string) {
-	return state, ""
+	s := state.(*MatchState)
+
+	// The challenge RPC signals the match when the friend declines, it closes on the next tick.
+	if data == challengeDeclinedSignal && s.challenge {
+		s.challengeDeclined = true
+	}
+
+	return s, ""
 }
 
 func (m *MatchHandler) MatchTerminate(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, graceSeconds int) interface{} {
 	return state
 }
 
+// Bring a newly joined spectator up to date. They get the start of the round in progress, with the current board,
+// so they learn the mark assignments and board dimensions, or the result of the last round if there's none.
+func (m *MatchHandler) sendSpectatorState(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState, presence runtime.Presence, t time.Time) {
+	if s.game == nil {
+		return
+	}
+
+	var opCode api.OpCode
+	var msg proto.Message
+	if s.playing {
+		opCode = api.OpCode_OPCODE_START
+		msg = startMessage(s, t)
+	} else {
+		opCode = api.OpCode_OPCODE_DONE
+		msg = doneMessage(s, t)
+	}
+
+	buf, err := m.marshaler.Marshal(msg)
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+		return
+	}
+	_ = dispatcher.BroadcastMessage(int64(opCode), buf, []runtime.Presence{presence}, nil, true)
+}
+
+// Stop the clock once the round has been decided, record it, and add it to the series score. If that decides the
+// series, the result goes on the leaderboard and the players vote on a rematch. Otherwise the next round is scheduled.
+func endRound(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, s *MatchState) {
+	s.playing = false
+	s.deadlineRemainingTicks = 0
+	clear(s.disconnectRemainingTicks)
+	cancelAiTurn(s)
+
+	if winner := s.game.Outcome().Winner; winner != api.Mark_MARK_UNSPECIFIED {
+		for userID, mark := range s.marks {
+			if mark == winner {
+				s.seriesScore[userID]++
+			}
+		}
+	}
+
+	recordGame(ctx, nk, logger, s)
+	clearActiveMatch(ctx, nk, logger, s)
+
+	if decided, winnerUserID := s.SeriesResult(); decided {
+		recordSeriesResult(ctx, nk, logger, s, winnerUserID)
+		s.rematchVotes = make(map[string]bool, 2)
+		s.rematchRemainingTicks = rematchTimeoutSec * tickRate
+	} else {
+		s.nextRoundRemainingTicks = delayBetweenRoundsSec * tickRate
+	}
+}
+
+// Collect rematch votes sent by the players since the last round completed. Returns true once both players have
+// agreed. If either player declines, or they run out of time to agree, the match ends: the players are removed and
+// the label reopens so the match can be found by someone else.
+func (m *MatchHandler) rematchAgreed(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState, messages []runtime.MatchData, t time.Time) bool {
+	voted := false
+	declined := false
+	for _, message := range messages {
+		if spectator, ok := s.spectators[message.GetUserId()]; ok {
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{spectator}, nil, true)
+			continue
+		}
+		if _, ok := s.presences[message.GetUserId()]; !ok {
+			continue
+		}
+
+		switch api.OpCode(message.GetOpCode()) {
+		case api.OpCode_OPCODE_REMATCH_REQUEST, api.OpCode_OPCODE_REMATCH_ACCEPT:
+			if !s.rematchVotes[message.GetUserId()] {
+				s.rematchVotes[message.GetUserId()] = true
+				voted = true
+			}
+		case api.OpCode_OPCODE_REMATCH_DECLINE:
+			declined = true
+		}
+	}
+
+	if declined {
+		logger.Debug("rematch declined")
+		m.endRematch(logger, dispatcher, s)
+		return false
+	}
+
+	// The AI is always happy to play again.
+	agreed := true
+	for userID := range s.presences {
+		if userID != aiUserId && !s.rematchVotes[userID] {
+			agreed = false
+			break
+		}
+	}
+	if agreed {
+		return true
+	}
+
+	// Let the players know who is waiting on whom.
+	if voted {
+		rematch := &api.Rematch{
+			Accepted: make([]string, 0, len(s.rematchVotes)),
+			Deadline: t.Add(time.Duration(s.rematchRemainingTicks/tickRate) * time.Second).Unix(),
+		}
+		for userID := range s.rematchVotes {
+			rematch.Accepted = append(rematch.Accepted, userID)
+		}
+
+		buf, err := m.marshaler.Marshal(rematch)
+		if err != nil {
+			logger.Error("error encoding message: %v", err)
+		} else {
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REMATCH_REQUEST), buf, playerPresences(s), nil, true)
+		}
+	}
+
+	s.rematchRemainingTicks--
+	if s.rematchRemainingTicks <= 0 {
+		logger.Debug("rematch vote timed out")
+		m.endRematch(logger, dispatcher, s)
+	}
+	return false
+}
+
+// End the match after a declined or expired rematch vote. Players are told and removed, and the match advertises
+// itself as open again unless it was reserved for them.
+func (m *MatchHandler) endRematch(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState) {
+	players := playerPresences(s)
+	_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REMATCH_DECLINE), nil, nil, nil, true)
+
+	for userID := range s.presences {
+		delete(s.presences, userID)
+	}
+	s.ai = false
+	resetSeries(s)
+
+	if len(players) > 0 {
+		if err := dispatcher.MatchKick(players); err != nil {
+			logger.Error("error kicking players: %v", err)
+		}
+	}
+
+	labelChanged := false
+	if s.label.Ai != 0 {
+		s.label.Ai = 0
+		labelChanged = true
+	}
+	if s.label.Open != 1 && s.Public() {
+		s.label.Open = 1
+		labelChanged = true
+	}
+	if labelChanged {
+		updateLabel(logger, dispatcher, s.label)
+	}
+}
+
+// Forget the last round and series, so the next game starts from scratch with fresh marks.
+func resetSeries(s *MatchState) {
+	cancelAiTurn(s)
+	s.game = nil
+	s.marks = nil
+	s.roundNumber = 0
+	s.seriesScore = nil
+	s.nextRoundRemainingTicks = 0
+	s.rematchVotes = nil
+	s.rematchRemainingTicks = 0
+}
+
+// The connected human players, leaving out the AI and any spectators.
+func playerPresences(s *MatchState) []runtime.Presence {
+	players := make([]runtime.Presence, 0, 2)
+	for userID, presence := range s.presences {
+		if presence != nil && userID != aiUserId {
+			players = append(players, presence)
+		}
+	}
+	return players
+}
+
+func startMessage(s *MatchState, t time.Time) *api.Start {
+	return &api.Start{
+		Board:        s.game.Board(),
+		Marks:        s.marks,
+		Mark:         s.game.Mark(),
+		Deadline:     t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
+		Width:        int32(s.config.Width),
+		Height:       int32(s.config.Height),
+		WinLength:    int32(s.config.WinLength),
+		RoundNumber:  s.roundNumber,
+		SeriesLength: int32(s.seriesLength),
+		SeriesScore:  s.seriesScore,
+	}
+}
+
+func doneMessage(s *MatchState, t time.Time) *api.Done {
+	outcome := s.game.Outcome()
+	seriesOver, _ := s.SeriesResult()
+	done := &api.Done{
+		Board:           s.game.Board(),
+		Winner:          outcome.Winner,
+		WinnerPositions: outcome.WinnerPositions,
+		RoundNumber:     s.roundNumber,
+		SeriesScore:     s.seriesScore,
+		SeriesOver:      seriesOver,
+	}
+	if s.nextRoundRemainingTicks > 0 {
+		done.NextGameStart = t.Add(time.Duration(s.nextRoundRemainingTicks/tickRate) * time.Second).Unix()
+	}
+	if s.rematchRemainingTicks > 0 {
+		done.RematchDeadline = t.Add(time.Duration(s.rematchRemainingTicks/tickRate) * time.Second).Unix()
+	}
+	return done
+}
+
+func updateLabel(logger runtime.Logger, dispatcher runtime.MatchDispatcher, label *MatchLabel) {
+	if labelJSON, err := json.Marshal(label); err != nil {
+		logger.Error("error encoding label: %v", err)
+	} else {
+		if err := dispatcher.MatchLabelUpdate(string(labelJSON)); err != nil {
+			logger.Error("error updating label: %v", err)
+		}
+	}
+}
+
+// Read a numeric match init parameter. Values may arrive as any Go integer type when the match is created from
+// server code, or as float64 when they have been through JSON.
+func intParam(params map[string]interface{}, key string, defaultValue int) int {
+	switch v := params[key].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return defaultValue
+	}
+}
+
+// Read a list of strings from match init parameters, as a Go slice or as it comes out of JSON.
+func stringsParam(params map[string]interface{}, key string) []string {
+	switch v := params[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if str, ok := value.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// Read a list of integers from match init parameters, as a Go slice or as it comes out of JSON.
+func int32sParam(params map[string]interface{}, key string) []int32 {
+	switch v := params[key].(type) {
+	case []int32:
+		return v
+	case []int:
+		values := make([]int32, 0, len(v))
+		for _, value := range v {
+			values = append(values, int32(value))
+		}
+		return values
+	case []interface{}:
+		values := make([]int32, 0, len(v))
+		for _, value := range v {
+			if num, ok := value.(float64); ok {
+				values = append(values, int32(num))
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// Series are played as best of 1, 3, 5 or 7 rounds.
+func validSeriesLength(seriesLength int) bool {
+	switch seriesLength {
+	case 1, 3, 5, 7:
+		return true
+	default:
+		return false
+	}
+}
+
 func calculateDeadlineTicks(l *MatchLabel) int64 {
 	if l.Fast == 1 {
 		return turnTimeFastSec * tickRate
@@ -597,3 +1138,48 @@ func calculateDeadlineTicks(l *MatchLabel) int64 {
 		return turnTimeNormalSec * tickRate
 	}
 }
+
+// Put the result of a series on the leaderboard for the match's mode once it has been decided: ranked series rate
+// both players, and count towards the weekly and season leaderboards, others add a win for the winner. The winner is
+// empty if the series was drawn.
+func recordSeriesResult(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, s *MatchState, winnerUserID string) {
+	leaderboardId := matchLeaderboardId(s.label)
+	if !leaderboards[leaderboardId].rated {
+		if winnerUserID != "" {
+			if err := recordSeriesWin(ctx, nk, logger, winnerUserID, leaderboardId); err != nil {
+				logger.Error("failed recording series win: %v", err)
+			}
+		}
+		return
+	}
+
+	var userIDs [2]string
+	i := 0
+	for userID := range s.marks {
+		if i < len(userIDs) {
+			userIDs[i] = userID
+			i++
+		}
+	}
+	if i < len(userIDs) {
+		logger.Error("cannot rate a series with %d players", i)
+		return
+	}
+
+	score := 0.5
+	switch winnerUserID {
+	case userIDs[0]:
+		score = 1
+	case userIDs[1]:
+		score = 0
+	}
+
+	if err := updateRatings(ctx, nk, logger, leaderboardId, s.label.Fast == 1, userIDs, score); err != nil {
+		logger.Error("failed updating ratings: %v", err)
+	}
+	if winnerUserID != "" {
+		if err := recordSeriesWin(ctx, nk, logger, winnerUserID, weeklyLeaderboardId, seasonLeaderboardId); err != nil {
+			logger.Error("failed recording series win: %v", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var gameEndReasons = map[string]api.GameEndReason{
+	"line":       api.GameEndReason_GAME_END_REASON_LINE,
+	"tie":        api.GameEndReason_GAME_END_REASON_TIE,
+	"forfeit":    api.GameEndReason_GAME_END_REASON_FORFEIT,
+	"disconnect": api.GameEndReason_GAME_END_REASON_DISCONNECT,
+}
+
+// List the caller's finished games, most recent first, a page at a time.
+func rpcListMatchHistory(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcListMatchHistoryRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		limit := 10
+		if request.Limit > 0 && request.Limit < 100 {
+			limit = int(request.Limit)
+		}
+
+		objects, cursor, err := nk.StorageList(ctx, userID, userID, matchHistoryCollection, limit, request.Cursor)
+		if err != nil {
+			logger.Error("error listing match history: %v", err)
+			return "", errInternalError
+		}
+
+		games := make([]*api.MatchHistoryGame, 0, len(objects))
+		for _, object := range objects {
+			record := &gameRecord{}
+			if err := json.Unmarshal([]byte(object.Value), record); err != nil {
+				logger.Warn("error decoding match history %s: %v", object.Key, err)
+				continue
+			}
+			games = append(games, record.historyGame())
+		}
+
+		response, err := marshaler.Marshal(&api.RpcListMatchHistoryResponse{Games: games, Cursor: cursor})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+func (r *gameRecord) historyGame() *api.MatchHistoryGame {
+	history := &api.MatchHistoryGame{
+		MatchId:   r.MatchID,
+		Round:     r.Round,
+		Fast:      r.Fast,
+		Ranked:    r.Ranked,
+		Width:     int32(r.Width),
+		Height:    int32(r.Height),
+		WinLength: int32(r.WinLength),
+		Players:   make([]*api.MatchHistoryPlayer, 0, len(r.Players)),
+		Moves:     make([]*api.MatchHistoryMove, 0, len(r.Moves)),
+		Winner:    r.Winner,
+		Reason:    gameEndReasons[r.Reason],
+		StartedAt: r.StartedAt,
+		EndedAt:   r.EndedAt,
+		ReplayId:  gameRecordKey(r),
+	}
+	for _, player := range r.Players {
+		history.Players = append(history.Players, &api.MatchHistoryPlayer{
+			UserId:   player.UserID,
+			Username: player.Username,
+			Mark:     player.Mark,
+			Rating:   player.Rating,
+		})
+	}
+	for _, move := range r.Moves {
+		history.Moves = append(history.Moves, &api.MatchHistoryMove{
+			Position: move.Position,
+			Mark:     move.Mark,
+			Time:     move.Time,
+		})
+	}
+	return history
+}
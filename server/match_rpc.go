@@ -17,10 +17,13 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -38,11 +41,38 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 			return "", errUnmarshal
 		}
 
+		config := boardConfig(request.Width, request.Height, request.WinLength)
+		if err := config.Validate(); err != nil {
+			logger.Debug("invalid board requested: %v", err)
+			return "", errBadInput
+		}
+
+		seriesLength := 1
+		if request.SeriesLength > 0 {
+			seriesLength = int(request.SeriesLength)
+		}
+		if !validSeriesLength(seriesLength) {
+			return "", errBadInput
+		}
+
 		// If AI flag is set just create a brand-new match
 		if request.Ai {
+			if config != game.DefaultConfig() {
+				// The AI model has only been trained on the classic board.
+				return "", errAiBoardUnsupported
+			}
+
+			difficulty := request.Difficulty
+			if _, ok := api.Difficulty_name[int32(difficulty)]; !ok {
+				return "", errBadInput
+			}
+			if difficulty == api.Difficulty_DIFFICULTY_UNSPECIFIED {
+				difficulty = api.Difficulty_DIFFICULTY_HARD
+			}
+
 			matchID, err := nk.MatchCreate(
 				ctx, moduleName, map[string]interface{}{
-					"ai": true, "fast": request.Fast})
+					"ai": true, "fast": request.Fast, "series_length": seriesLength, "difficulty": int(difficulty)})
 			if err != nil {
 				logger.Error("error creating match: %v", err)
 				return "", errInternalError
@@ -63,9 +93,10 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 		maxSize := 1
 		var fast int
 		if request.Fast {
-			fast = 0
+			fast = 1
 		}
-		query := fmt.Sprintf("+label.open:1 +label.fast:%d", fast)
+		query := fmt.Sprintf("+label.open:1 -label.ai:1 +label.fast:%d +label.width:%d +label.height:%d +label.win_length:%d +label.series_length:%d",
+			fast, config.Width, config.Height, config.WinLength, seriesLength)
 
 		matchIDs := make([]string, 0, 10)
 		matches, err := nk.MatchList(ctx, 10, true, "", nil, &maxSize, query)
@@ -73,7 +104,6 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 			logger.Error("error listing matches: %v", err)
 			return "", errInternalError
 		}
-		fmt.Print(matches, runtime.RUNTIME_CTX_USER_ID)
 
 		if len(matches) > 0 {
 			// There are one or more ongoing matches the user could join.
@@ -82,7 +112,9 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 			}
 		} else {
 			// No available matches found, create a new one.
-			matchID, err := nk.MatchCreate(ctx, moduleName, map[string]interface{}{"fast": request.Fast})
+			matchID, err := nk.MatchCreate(ctx, moduleName, map[string]interface{}{
+				"fast": request.Fast, "width": config.Width, "height": config.Height, "win_length": config.WinLength,
+				"series_length": seriesLength})
 			if err != nil {
 				logger.Error("error creating match: %v", err)
 				return "", errInternalError
@@ -99,3 +131,77 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 		return string(response), nil
 	}
 }
+
+func rpcListLiveMatches(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcListLiveMatchesRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		limit := 10
+		if request.Limit > 0 && request.Limit < 100 {
+			limit = int(request.Limit)
+		}
+
+		// Matches stop advertising themselves as open once both players are in, so those are the ones worth watching.
+		// Private matches are only for those with the room code.
+		minSize := 1
+		matches, err := nk.MatchList(ctx, limit, true, "", &minSize, nil, "+label.open:0 -label.private:1")
+		if err != nil {
+			logger.Error("error listing matches: %v", err)
+			return "", errInternalError
+		}
+
+		liveMatches := make([]*api.LiveMatch, 0, len(matches))
+		for _, match := range matches {
+			label := &MatchLabel{}
+			if err := json.Unmarshal([]byte(match.GetLabel().GetValue()), label); err != nil {
+				logger.Warn("error decoding label of match %s: %v", match.MatchId, err)
+				continue
+			}
+
+			liveMatches = append(liveMatches, &api.LiveMatch{
+				MatchId:    match.MatchId,
+				Spectators: int32(label.Spectators),
+				Fast:       label.Fast == 1,
+				Width:      int32(label.Width),
+				Height:     int32(label.Height),
+				WinLength:  int32(label.WinLength),
+			})
+		}
+		sort.SliceStable(liveMatches, func(i, j int) bool {
+			return liveMatches[i].Spectators > liveMatches[j].Spectators
+		})
+
+		response, err := marshaler.Marshal(&api.RpcListLiveMatchesResponse{Matches: liveMatches})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Board dimensions requested by the player, falling back to the classic 3x3 board for anything left unset.
+func boardConfig(width, height, winLength int32) game.Config {
+	config := game.DefaultConfig()
+	if width > 0 {
+		config.Width = int(width)
+	}
+	if height > 0 {
+		config.Height = int(height)
+	}
+	if winLength > 0 {
+		config.WinLength = int(winLength)
+	}
+	return config
+}
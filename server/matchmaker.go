@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	matchmakerModeFast   = "fast"
+	matchmakerModeNormal = "normal"
+
+	matchmakerDefaultRegion = "global"
+	matchmakerMaxRegionLen  = 32
+
+	// Players are first only paired with opponents rated within this range of them...
+	matchmakerBaseRatingWindow = 100
+	// ...which widens the longer their ticket waits...
+	matchmakerRatingWindowPerSec = 10
+	// ...up to this limit.
+	matchmakerMaxRatingWindow = 600
+)
+
+// Fill in the ranked properties and query of a matchmaker ticket before it reaches the matchmaker. The rating is
+// always looked up on the server so clients cannot misreport it, and the query accepts any opponent within the widest
+// rating range. The override below narrows it down depending on how long the tickets have been waiting.
+func beforeMatchmakerAdd(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *rtapi.Envelope) (*rtapi.Envelope, error) {
+	userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+	if !ok {
+		return nil, errNoUserIdFound
+	}
+
+	add := in.GetMatchmakerAdd()
+	if add == nil {
+		return in, nil
+	}
+	if add.StringProperties == nil {
+		add.StringProperties = make(map[string]string, 2)
+	}
+	if add.NumericProperties == nil {
+		add.NumericProperties = make(map[string]float64, 2)
+	}
+
+	mode := add.StringProperties["mode"]
+	switch mode {
+	case matchmakerModeFast, matchmakerModeNormal:
+	case "":
+		mode = matchmakerModeNormal
+	default:
+		return nil, errBadInput
+	}
+	region := add.StringProperties["region"]
+	if region == "" {
+		region = matchmakerDefaultRegion
+	}
+	if !validRegion(region) {
+		// It goes into the ticket query as it is, where anything else could add clauses of its own.
+		return nil, errBadInput
+	}
+
+	rating, err := readRating(ctx, nk, userID, mode == matchmakerModeFast)
+	if err != nil {
+		logger.Error("error reading rating: %v", err)
+		return nil, errInternalError
+	}
+
+	add.MinCount = 2
+	add.MaxCount = 2
+	add.CountMultiple = nil
+	add.StringProperties["mode"] = mode
+	add.StringProperties["region"] = region
+	add.NumericProperties["rating"] = math.Round(rating.Rating)
+	add.NumericProperties["created"] = float64(time.Now().UTC().Unix())
+	add.Query = fmt.Sprintf("+properties.mode:%s +properties.region:%s +properties.rating:>=%d +properties.rating:<=%d",
+		mode, region, int(rating.Rating)-matchmakerMaxRatingWindow, int(rating.Rating)+matchmakerMaxRatingWindow)
+
+	return in, nil
+}
+
+// Regions are lower case letters, digits, dashes and underscores, such as eu-west.
+func validRegion(region string) bool {
+	if len(region) == 0 || len(region) > matchmakerMaxRegionLen {
+		return false
+	}
+	for _, r := range region {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Keep only the candidate matches whose players are rated close enough together, given how long they have waited,
+// closest ratings first. Each ticket ends up in at most one match.
+func matchmakerOverride(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, candidateMatches [][]runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry {
+	now := time.Now().UTC().Unix()
+
+	type candidate struct {
+		entries []runtime.MatchmakerEntry
+		spread  float64
+	}
+	candidates := make([]candidate, 0, len(candidateMatches))
+	for _, entries := range candidateMatches {
+		minRating, maxRating := math.Inf(1), math.Inf(-1)
+		window := 0.0
+		for _, entry := range entries {
+			properties := entry.GetProperties()
+			rating, _ := properties["rating"].(float64)
+			created, _ := properties["created"].(float64)
+
+			minRating = math.Min(minRating, rating)
+			maxRating = math.Max(maxRating, rating)
+			window = math.Max(window, ratingWindow(now-int64(created)))
+		}
+
+		if spread := maxRating - minRating; spread <= window {
+			candidates = append(candidates, candidate{entries: entries, spread: spread})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].spread < candidates[j].spread
+	})
+
+	matches := make([][]runtime.MatchmakerEntry, 0, len(candidates))
+	matched := make(map[string]bool, len(candidates)*2)
+candidates:
+	for _, c := range candidates {
+		for _, entry := range c.entries {
+			if matched[entry.GetTicket()] {
+				continue candidates
+			}
+		}
+		for _, entry := range c.entries {
+			matched[entry.GetTicket()] = true
+		}
+		matches = append(matches, c.entries)
+	}
+
+	return matches
+}
+
+// Create an authoritative match for players paired by the matchmaker. Only they can take the player slots.
+func matchmakerMatched(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) (string, error) {
+	if len(entries) == 0 {
+		return "", errInternalError
+	}
+
+	mode, _ := entries[0].GetProperties()["mode"].(string)
+	userIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		userIDs = append(userIDs, entry.GetPresence().GetUserId())
+	}
+
+	matchID, err := nk.MatchCreate(ctx, moduleName, map[string]interface{}{
+		"fast": mode == matchmakerModeFast, "ranked": true, "user_ids": userIDs})
+	if err != nil {
+		logger.Error("error creating match: %v", err)
+		return "", errInternalError
+	}
+
+	logger.Info("new ranked match created %s for %v", matchID, userIDs)
+	return matchID, nil
+}
+
+// How far apart two players' ratings may be, once the longer waiting of them has been in the queue this long.
+func ratingWindow(waitSec int64) float64 {
+	if waitSec < 0 {
+		waitSec = 0
+	}
+	return math.Min(float64(matchmakerBaseRatingWindow+matchmakerRatingWindowPerSec*waitSec), matchmakerMaxRatingWindow)
+}
@@ -0,0 +1,510 @@
+// Package notation reads and writes games in a compact text notation, in the spirit of chess's PGN, so they can be
+// shared, attached to bug reports, or used to set up a board for the game engine.
+//
+// A game is a list of headers, one per line, followed by its moves:
+//
+//	[Date "2026.10.18"]
+//	[X "alice"]
+//	[O "bob"]
+//	[Mode "normal ranked"]
+//	[Board "3x3"]
+//	[WinLength "3"]
+//	[Start "2026-10-18T12:00:00.000Z"]
+//	[Result "1-0"]
+//	[Termination "line"]
+//
+//	1. b2 {2.104} a1 {1.530} 2. c1 {3.001} a3 {0.822}
+//	3. a2 {1.419} c3 {2.200} 4. b3 {4.010} b1 {1.097}
+//	5. c2 {1.873} 1-0
+//
+// X always moves first. A move is its cell's column, as a letter from a on the left, and its row, as a number from 1
+// at the top, so a1 is position 0 of the board. A comment after a move holds the seconds since the previous move, or
+// since the start of the game for the first, and needs a Start header to count from. The result, 1-0 if X won, 0-1
+// if O won, 1/2-1/2 for a draw or * if the game isn't over, ends the moves.
+//
+// Headers not listed on Game are ignored when parsing, and the moves are checked against the game's rules.
+package notation
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+// ErrSyntax is returned, wrapped with the details, for text that isn't a valid game.
+var ErrSyntax = errors.New("invalid game notation")
+
+const (
+	resultX       = "1-0"
+	resultO       = "0-1"
+	resultDraw    = "1/2-1/2"
+	resultOngoing = "*"
+
+	// Times are written to the millisecond, in UTC.
+	timeFormat = "2006-01-02T15:04:05.000Z07:00"
+	dateFormat = "2006.01.02"
+
+	// Move text is wrapped to lines no longer than this.
+	lineLength = 80
+)
+
+var terminations = map[game.Reason]string{
+	game.ReasonLine:       "line",
+	game.ReasonTie:        "tie",
+	game.ReasonForfeit:    "forfeit",
+	game.ReasonDisconnect: "disconnect",
+}
+
+// Player is one side of a game.
+type Player struct {
+	Name   string
+	ID     string
+	Rating float64
+}
+
+// Move is a position played, and when. The time is zero if it isn't known.
+type Move struct {
+	Position int32
+	Time     time.Time
+}
+
+// Game is a game of tic-tac-toe as written in the notation.
+type Game struct {
+	X      Player
+	O      Player
+	Config game.Config
+	Fast   bool
+	Ranked bool
+	// The match the game was played in, and which of its rounds it was.
+	MatchID string
+	Round   int32
+	// Zero if not known.
+	Start time.Time
+	End   time.Time
+	Moves []Move
+	// Unspecified for a draw, or a game that isn't over.
+	Winner api.Mark
+	// How the game ended, ReasonNone if it isn't over.
+	Termination game.Reason
+}
+
+// Coord returns the name of a board position, such as b2.
+func Coord(position int32, config game.Config) (string, error) {
+	if position < 0 || int(position) >= config.Width*config.Height {
+		return "", game.ErrInvalidPosition
+	}
+	x, y := int(position)%config.Width, int(position)/config.Width
+	return string(rune('a'+x)) + strconv.Itoa(y+1), nil
+}
+
+// ParseCoord returns the board position a name such as b2 refers to.
+func ParseCoord(coord string, config game.Config) (int32, error) {
+	if len(coord) < 2 || coord[0] < 'a' || coord[0] > 'z' {
+		return 0, fmt.Errorf("%w: bad move %q", ErrSyntax, coord)
+	}
+	x := int(coord[0] - 'a')
+	row, err := strconv.Atoi(coord[1:])
+	if err != nil || coord[1] == '0' || coord[1] == '+' {
+		return 0, fmt.Errorf("%w: bad move %q", ErrSyntax, coord)
+	}
+	y := row - 1
+	if x >= config.Width || y < 0 || y >= config.Height {
+		return 0, fmt.Errorf("move %q: %w", coord, game.ErrInvalidPosition)
+	}
+	return int32(y*config.Width + x), nil
+}
+
+// Result returns the game's result as it's written in the notation.
+func (g *Game) Result() string {
+	switch {
+	case g.Winner == api.Mark_MARK_X:
+		return resultX
+	case g.Winner == api.Mark_MARK_O:
+		return resultO
+	case g.Termination != game.ReasonNone:
+		return resultDraw
+	default:
+		return resultOngoing
+	}
+}
+
+// MarshalText writes the game in the notation. The moves must be legal on the game's board.
+func (g *Game) MarshalText() ([]byte, error) {
+	if err := g.Config.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := terminations[g.Termination]; !ok && g.Termination != game.ReasonNone {
+		return nil, fmt.Errorf("unknown termination %d", g.Termination)
+	}
+
+	var b bytes.Buffer
+	header := func(name, value string) {
+		b.WriteString("[" + name + " " + strconv.Quote(value) + "]\n")
+	}
+
+	if !g.Start.IsZero() {
+		header("Date", g.Start.UTC().Format(dateFormat))
+	}
+	header("X", playerName(g.X.Name))
+	header("O", playerName(g.O.Name))
+	if g.X.ID != "" {
+		header("XId", g.X.ID)
+	}
+	if g.O.ID != "" {
+		header("OId", g.O.ID)
+	}
+	if g.X.Rating != 0 {
+		header("XRating", strconv.FormatFloat(g.X.Rating, 'f', -1, 64))
+	}
+	if g.O.Rating != 0 {
+		header("ORating", strconv.FormatFloat(g.O.Rating, 'f', -1, 64))
+	}
+	header("Mode", mode(g.Fast, g.Ranked))
+	header("Board", fmt.Sprintf("%dx%d", g.Config.Width, g.Config.Height))
+	header("WinLength", strconv.Itoa(g.Config.WinLength))
+	if g.MatchID != "" {
+		header("Match", g.MatchID)
+	}
+	if g.Round != 0 {
+		header("Round", strconv.Itoa(int(g.Round)))
+	}
+	if !g.Start.IsZero() {
+		header("Start", g.Start.UTC().Format(timeFormat))
+	}
+	if !g.End.IsZero() {
+		header("End", g.End.UTC().Format(timeFormat))
+	}
+	header("Result", g.Result())
+	if g.Termination != game.ReasonNone {
+		header("Termination", terminations[g.Termination])
+	}
+	b.WriteByte('\n')
+
+	tokens := make([]string, 0, len(g.Moves)*3/2+1)
+	last := g.Start
+	for i, move := range g.Moves {
+		coord, err := Coord(move.Position, g.Config)
+		if err != nil {
+			return nil, fmt.Errorf("move %d: %w", i+1, err)
+		}
+		if i%2 == 0 {
+			coord = strconv.Itoa(i/2+1) + ". " + coord
+		}
+		if !g.Start.IsZero() && !move.Time.IsZero() {
+			coord += " {" + strconv.FormatFloat(move.Time.Sub(last).Seconds(), 'f', 3, 64) + "}"
+			last = move.Time
+		}
+		tokens = append(tokens, coord)
+	}
+	tokens = append(tokens, g.Result())
+
+	// Break lines between moves, never inside one.
+	width := 0
+	for i, token := range tokens {
+		if i > 0 {
+			if width+1+len(token) > lineLength {
+				b.WriteByte('\n')
+				width = 0
+			} else {
+				b.WriteByte(' ')
+				width++
+			}
+		}
+		b.WriteString(token)
+		width += len(token)
+	}
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalText reads a game written in the notation, as Parse does.
+func (g *Game) UnmarshalText(text []byte) error {
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*g = *parsed
+	return nil
+}
+
+// Parse reads a game written in the notation. The moves are played on a new board to check they follow the rules,
+// and that the result agrees with how the board ended up.
+func Parse(text []byte) (*Game, error) {
+	g := &Game{Config: game.DefaultConfig()}
+
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	lineNumber := 0
+	var moveText strings.Builder
+	result := ""
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "[") || moveText.Len() > 0 {
+			moveText.WriteString(line)
+			moveText.WriteByte('\n')
+			continue
+		}
+
+		name, value, err := parseHeader(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if err := g.setHeader(name, value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if name == "Result" {
+			result = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := g.Config.Validate(); err != nil {
+		return nil, err
+	}
+
+	moveResult, err := g.parseMoves(moveText.String())
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case result == "":
+		result = moveResult
+	case moveResult != "" && moveResult != result:
+		return nil, fmt.Errorf("%w: result %s doesn't match the Result header %s", ErrSyntax, moveResult, result)
+	}
+
+	if err := g.setResult(result); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// A header line, such as [X "alice"].
+func parseHeader(line string) (string, string, error) {
+	if !strings.HasSuffix(line, "]") {
+		return "", "", fmt.Errorf("%w: bad header %q", ErrSyntax, line)
+	}
+	name, quoted, ok := strings.Cut(line[1:len(line)-1], " ")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("%w: bad header %q", ErrSyntax, line)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(quoted))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: bad header %q", ErrSyntax, line)
+	}
+	return name, value, nil
+}
+
+func (g *Game) setHeader(name, value string) error {
+	var err error
+	switch name {
+	case "X":
+		g.X.Name = value
+		if value == "?" {
+			g.X.Name = ""
+		}
+	case "O":
+		g.O.Name = value
+		if value == "?" {
+			g.O.Name = ""
+		}
+	case "XId":
+		g.X.ID = value
+	case "OId":
+		g.O.ID = value
+	case "XRating":
+		g.X.Rating, err = strconv.ParseFloat(value, 64)
+	case "ORating":
+		g.O.Rating, err = strconv.ParseFloat(value, 64)
+	case "Mode":
+		for _, word := range strings.Fields(value) {
+			switch word {
+			case "fast":
+				g.Fast = true
+			case "ranked":
+				g.Ranked = true
+			case "normal", "casual":
+			default:
+				err = errors.New("unknown mode")
+			}
+		}
+	case "Board":
+		w, h, ok := strings.Cut(value, "x")
+		if !ok {
+			err = errors.New("board should be written as widthxheight")
+			break
+		}
+		if g.Config.Width, err = strconv.Atoi(w); err == nil {
+			g.Config.Height, err = strconv.Atoi(h)
+		}
+	case "WinLength":
+		g.Config.WinLength, err = strconv.Atoi(value)
+	case "Match":
+		g.MatchID = value
+	case "Round":
+		var round int64
+		round, err = strconv.ParseInt(value, 10, 32)
+		g.Round = int32(round)
+	case "Start":
+		g.Start, err = time.Parse(timeFormat, value)
+	case "End":
+		g.End, err = time.Parse(timeFormat, value)
+	case "Termination":
+		g.Termination = game.ReasonNone
+		for reason, termination := range terminations {
+			if termination == value {
+				g.Termination = reason
+			}
+		}
+		if g.Termination == game.ReasonNone {
+			err = errors.New("unknown termination")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: bad %s header %q: %v", ErrSyntax, name, value, err)
+	}
+	return nil
+}
+
+// Read the moves, playing them on a new board, and return the result they end with, if any.
+func (g *Game) parseMoves(text string) (string, error) {
+	board := game.New(g.Config)
+	last := g.Start
+	result := ""
+	for text = strings.TrimSpace(text); text != ""; text = strings.TrimSpace(text) {
+		if result != "" {
+			return "", fmt.Errorf("%w: moves after the result", ErrSyntax)
+		}
+
+		if text[0] == '{' {
+			end := strings.IndexByte(text, '}')
+			if end < 0 {
+				return "", fmt.Errorf("%w: unclosed comment", ErrSyntax)
+			}
+			comment := strings.TrimSpace(text[1:end])
+			text = text[end+1:]
+
+			if len(g.Moves) == 0 || !g.Moves[len(g.Moves)-1].Time.IsZero() {
+				return "", fmt.Errorf("%w: move time {%s} not following a move", ErrSyntax, comment)
+			}
+			if g.Start.IsZero() {
+				return "", fmt.Errorf("%w: move times need a Start header", ErrSyntax)
+			}
+			elapsed, err := time.ParseDuration(comment + "s")
+			if err != nil {
+				return "", fmt.Errorf("%w: bad move time {%s}", ErrSyntax, comment)
+			}
+			last = last.Add(elapsed)
+			g.Moves[len(g.Moves)-1].Time = last
+			continue
+		}
+
+		// A move, move number or result runs up to the next space or comment.
+		end := strings.IndexAny(text, " \t\r\n{")
+		if end < 0 {
+			end = len(text)
+		}
+		token := text[:end]
+		text = text[end:]
+
+		switch {
+		case token == resultX, token == resultO, token == resultDraw, token == resultOngoing:
+			result = token
+		case strings.HasSuffix(token, "."):
+			number, err := strconv.Atoi(strings.TrimSuffix(token, "."))
+			if err != nil || number != len(g.Moves)/2+1 || len(g.Moves)%2 != 0 {
+				return "", fmt.Errorf("%w: unexpected move number %q", ErrSyntax, token)
+			}
+		default:
+			position, err := ParseCoord(token, g.Config)
+			if err != nil {
+				return "", fmt.Errorf("move %d: %w", len(g.Moves)+1, err)
+			}
+			if err := board.ApplyMove(board.Mark(), position); err != nil {
+				return "", fmt.Errorf("move %d %s: %w", len(g.Moves)+1, token, err)
+			}
+			g.Moves = append(g.Moves, Move{Position: position})
+		}
+	}
+
+	// A line or a full board ends the game, whatever the headers say.
+	if outcome := board.Outcome(); outcome.Done {
+		if g.Termination != game.ReasonNone && g.Termination != outcome.Reason {
+			return "", fmt.Errorf("%w: the board ended in a %s, not a %s", ErrSyntax,
+				terminations[outcome.Reason], terminations[g.Termination])
+		}
+		g.Termination = outcome.Reason
+		g.Winner = outcome.Winner
+	} else if g.Termination == game.ReasonLine || g.Termination == game.ReasonTie {
+		return "", fmt.Errorf("%w: the board doesn't end in a %s", ErrSyntax, terminations[g.Termination])
+	}
+	return result, nil
+}
+
+// Check the result agrees with the moves and termination, filling in whichever of them is missing.
+func (g *Game) setResult(result string) error {
+	winner := api.Mark_MARK_UNSPECIFIED
+	switch result {
+	case resultX:
+		winner = api.Mark_MARK_X
+	case resultO:
+		winner = api.Mark_MARK_O
+	case resultDraw, resultOngoing, "":
+	default:
+		return fmt.Errorf("%w: unknown result %q", ErrSyntax, result)
+	}
+
+	switch g.Termination {
+	case game.ReasonLine, game.ReasonTie:
+		// Already worked out from the board.
+		if result != "" && result != g.Result() {
+			return fmt.Errorf("%w: result %s doesn't match the board", ErrSyntax, result)
+		}
+	case game.ReasonForfeit, game.ReasonDisconnect:
+		if winner == api.Mark_MARK_UNSPECIFIED {
+			return fmt.Errorf("%w: a game ending in a %s needs a winner", ErrSyntax, terminations[g.Termination])
+		}
+		g.Winner = winner
+	default:
+		switch result {
+		case resultDraw:
+			return fmt.Errorf("%w: a draw needs a full board", ErrSyntax)
+		case resultX, resultO:
+			// Won with the board still open, by the opponent running out of time.
+			g.Winner = winner
+			g.Termination = game.ReasonForfeit
+		}
+	}
+	return nil
+}
+
+// Unknown players are written as ?, as in PGN.
+func playerName(name string) string {
+	if name == "" {
+		return "?"
+	}
+	return name
+}
+
+func mode(fast, ranked bool) string {
+	speed, rating := "normal", "casual"
+	if fast {
+		speed = "fast"
+	}
+	if ranked {
+		rating = "ranked"
+	}
+	return speed + " " + rating
+}
@@ -0,0 +1,328 @@
+package notation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+var (
+	gomoku = game.Config{Width: 15, Height: 15, WinLength: 5}
+	wide   = game.Config{Width: 5, Height: 3, WinLength: 3}
+)
+
+// Moves at the given positions, untimed.
+func moves(positions ...int32) []Move {
+	m := make([]Move, len(positions))
+	for i, pos := range positions {
+		m[i].Position = pos
+	}
+	return m
+}
+
+func TestCoord(t *testing.T) {
+	tests := []struct {
+		config   game.Config
+		position int32
+		coord    string
+	}{
+		{game.DefaultConfig(), 0, "a1"},
+		{game.DefaultConfig(), 2, "c1"},
+		{game.DefaultConfig(), 4, "b2"},
+		{game.DefaultConfig(), 8, "c3"},
+		{gomoku, 135, "a10"},
+		{gomoku, 224, "o15"},
+		{wide, 4, "e1"},
+		{wide, 10, "a3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.coord, func(t *testing.T) {
+			coord, err := Coord(tt.position, tt.config)
+			if err != nil || coord != tt.coord {
+				t.Errorf("Coord(%d) = %q, %v, want %q", tt.position, coord, err, tt.coord)
+			}
+			position, err := ParseCoord(tt.coord, tt.config)
+			if err != nil || position != tt.position {
+				t.Errorf("ParseCoord(%q) = %d, %v, want %d", tt.coord, position, err, tt.position)
+			}
+		})
+	}
+}
+
+func TestCoordRejected(t *testing.T) {
+	for _, position := range []int32{-1, 9} {
+		if _, err := Coord(position, game.DefaultConfig()); !errors.Is(err, game.ErrInvalidPosition) {
+			t.Errorf("Coord(%d) = %v, want %v", position, err, game.ErrInvalidPosition)
+		}
+	}
+
+	tests := []struct {
+		coord string
+		err   error
+	}{
+		{"", ErrSyntax},
+		{"a", ErrSyntax},
+		{"A1", ErrSyntax},
+		{"1a", ErrSyntax},
+		{"a0", ErrSyntax},
+		{"a01", ErrSyntax},
+		{"a+1", ErrSyntax},
+		{"a-1", game.ErrInvalidPosition},
+		{"d1", game.ErrInvalidPosition},
+		{"a4", game.ErrInvalidPosition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.coord, func(t *testing.T) {
+			if _, err := ParseCoord(tt.coord, game.DefaultConfig()); !errors.Is(err, tt.err) {
+				t.Errorf("ParseCoord(%q) = %v, want %v", tt.coord, err, tt.err)
+			}
+		})
+	}
+}
+
+// The game from the package documentation.
+var documented = &Game{
+	X:      Player{Name: "alice"},
+	O:      Player{Name: "bob"},
+	Config: game.DefaultConfig(),
+	Ranked: true,
+	Start:  time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	Moves: []Move{
+		{4, time.Date(2026, 10, 18, 12, 0, 2, 104e6, time.UTC)},
+		{0, time.Date(2026, 10, 18, 12, 0, 3, 634e6, time.UTC)},
+		{2, time.Date(2026, 10, 18, 12, 0, 6, 635e6, time.UTC)},
+		{6, time.Date(2026, 10, 18, 12, 0, 7, 457e6, time.UTC)},
+		{3, time.Date(2026, 10, 18, 12, 0, 8, 876e6, time.UTC)},
+		{8, time.Date(2026, 10, 18, 12, 0, 11, 76e6, time.UTC)},
+		{7, time.Date(2026, 10, 18, 12, 0, 15, 86e6, time.UTC)},
+		{1, time.Date(2026, 10, 18, 12, 0, 16, 183e6, time.UTC)},
+		{5, time.Date(2026, 10, 18, 12, 0, 18, 56e6, time.UTC)},
+	},
+	Winner:      api.Mark_MARK_X,
+	Termination: game.ReasonLine,
+}
+
+const documentedText = `[Date "2026.10.18"]
+[X "alice"]
+[O "bob"]
+[Mode "normal ranked"]
+[Board "3x3"]
+[WinLength "3"]
+[Start "2026-10-18T12:00:00.000Z"]
+[Result "1-0"]
+[Termination "line"]
+
+1. b2 {2.104} a1 {1.530} 2. c1 {3.001} a3 {0.822} 3. a2 {1.419} c3 {2.200}
+4. b3 {4.010} b1 {1.097} 5. c2 {1.873} 1-0
+`
+
+func TestMarshalText(t *testing.T) {
+	text, err := documented.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != documentedText {
+		t.Errorf("MarshalText() =\n%s\nwant\n%s", text, documentedText)
+	}
+}
+
+func TestParse(t *testing.T) {
+	g, err := Parse([]byte(documentedText))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, documented) {
+		t.Errorf("Parse() = %+v, want %+v", g, documented)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 6e6, time.UTC)
+	// A long game on a big board, no five in a row across the two rows it fills.
+	long := make([]Move, 30)
+	for i := range long {
+		long[i] = Move{Position: int32(i), Time: start.Add(time.Duration(i+1) * 1500 * time.Millisecond)}
+	}
+
+	tests := []struct {
+		name string
+		game *Game
+	}{
+		{"documented", documented},
+		{"every header", &Game{
+			X:           Player{Name: "alice", ID: "a1b2", Rating: 1512.25},
+			O:           Player{Name: "bob", ID: "c3d4", Rating: 1488},
+			Config:      game.DefaultConfig(),
+			Fast:        true,
+			Ranked:      true,
+			MatchID:     "0b5e.nakama",
+			Round:       3,
+			Start:       start,
+			End:         start.Add(time.Minute),
+			Moves:       moves(0, 3, 1, 4, 2),
+			Winner:      api.Mark_MARK_X,
+			Termination: game.ReasonLine,
+		}},
+		{"names with quotes", &Game{
+			X:      Player{Name: `the "X" player`},
+			O:      Player{Name: "[O]"},
+			Config: game.DefaultConfig(),
+		}},
+		{"ongoing", &Game{Config: game.DefaultConfig(), Moves: moves(4, 0)}},
+		{"tie", &Game{
+			Config:      game.DefaultConfig(),
+			Moves:       moves(0, 1, 2, 4, 3, 5, 7, 6, 8),
+			Termination: game.ReasonTie,
+		}},
+		{"win for O", &Game{
+			Config:      game.DefaultConfig(),
+			Moves:       moves(0, 6, 1, 7, 5, 8),
+			Winner:      api.Mark_MARK_O,
+			Termination: game.ReasonLine,
+		}},
+		{"forfeit on a big board", &Game{
+			Config:      gomoku,
+			Moves:       moves(112, 113, 98),
+			Winner:      api.Mark_MARK_O,
+			Termination: game.ReasonForfeit,
+		}},
+		{"disconnect on a rectangular board", &Game{
+			Config:      wide,
+			Moves:       moves(14, 0),
+			Winner:      api.Mark_MARK_X,
+			Termination: game.ReasonDisconnect,
+		}},
+		{"win on a rectangular board", &Game{
+			Config:      wide,
+			Moves:       moves(4, 0, 8, 1, 12),
+			Winner:      api.Mark_MARK_X,
+			Termination: game.ReasonLine,
+		}},
+		{"long", &Game{Config: gomoku, Start: start, Moves: long}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.game.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, line := range strings.Split(string(text), "\n") {
+				if len(line) > lineLength && !strings.HasPrefix(line, "[") {
+					t.Errorf("line longer than %d: %q", lineLength, line)
+				}
+			}
+
+			var g Game
+			if err := g.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText(%s) = %v", text, err)
+			}
+			if !reflect.DeepEqual(&g, tt.game) {
+				t.Errorf("UnmarshalText(%s) = %+v, want %+v", text, &g, tt.game)
+			}
+		})
+	}
+}
+
+func TestParseFillsIn(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		winner      api.Mark
+		termination game.Reason
+	}{
+		{"no headers", "1. b2 a1 2. c1", api.Mark_MARK_UNSPECIFIED, game.ReasonNone},
+		{"line without a result", "1. a1 a2 2. b1 b2 3. c1", api.Mark_MARK_X, game.ReasonLine},
+		{"result in the headers only", "[Result \"1-0\"]\n\n1. a1 a2 2. b1 b2 3. c1", api.Mark_MARK_X, game.ReasonLine},
+		{"won on time", "1. b2 0-1", api.Mark_MARK_O, game.ReasonForfeit},
+		{"unknown headers", "[Event \"club night\"]\n\n1. b2 *", api.Mark_MARK_UNSPECIFIED, game.ReasonNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Parse([]byte(tt.text))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if g.Winner != tt.winner || g.Termination != tt.termination {
+				t.Errorf("Parse() won by %v by %v, want %v by %v", g.Winner, g.Termination, tt.winner, tt.termination)
+			}
+		})
+	}
+}
+
+func TestParseRejected(t *testing.T) {
+	const start = "[Start \"2026-10-18T12:00:00.000Z\"]\n\n"
+	tests := []struct {
+		name string
+		text string
+		// Nil for errors that only need to be errors.
+		err error
+	}{
+		{"unquoted header", "[X alice]\n\n1. b2", ErrSyntax},
+		{"unclosed header", "[X \"alice\"\n\n1. b2", ErrSyntax},
+		{"header without a value", "[X]\n\n1. b2", ErrSyntax},
+		{"bad board", "[Board \"3by3\"]\n\n1. b2", ErrSyntax},
+		{"bad rating", "[XRating \"high\"]\n\n1. b2", ErrSyntax},
+		{"unknown mode", "[Mode \"blitz\"]\n\n1. b2", ErrSyntax},
+		{"unknown termination", "[Termination \"timeout\"]\n\n1. b2", ErrSyntax},
+		{"bad start", "[Start \"yesterday\"]\n\n1. b2", ErrSyntax},
+		{"unknown result", "[Result \"2-0\"]\n\n1. b2", ErrSyntax},
+		{"board too small", "[Board \"2x2\"]\n\n1. a1", nil},
+		{"win length too long", "[WinLength \"4\"]\n\n1. a1", nil},
+		{"uppercase move", "1. B2", ErrSyntax},
+		{"move off the board", "1. d1", game.ErrInvalidPosition},
+		{"move below the board", "1. a4", game.ErrInvalidPosition},
+		{"cell taken", "1. b2 b2", game.ErrPositionTaken},
+		{"move after a line", "1. a1 a2 2. b1 b2 3. c1 c2", game.ErrGameOver},
+		{"wrong move number", "1. b2 a1 3. c1", ErrSyntax},
+		{"move number before O", "1. b2 2. a1", ErrSyntax},
+		{"bad move number", "x. b2", ErrSyntax},
+		{"move time without a start", "1. b2 {1.000}", ErrSyntax},
+		{"bad move time", start + "1. b2 {soon}", ErrSyntax},
+		{"unclosed move time", start + "1. b2 {1.000", ErrSyntax},
+		{"move time before the moves", start + "{1.000} 1. b2", ErrSyntax},
+		{"two move times", start + "1. b2 {1.000} {2.000}", ErrSyntax},
+		{"moves after the result", "1. b2 * a1", ErrSyntax},
+		{"result against the header", "[Result \"0-1\"]\n\n1. b2 1-0", ErrSyntax},
+		{"result against the board", "1. a1 a2 2. b1 b2 3. c1 0-1", ErrSyntax},
+		{"ongoing with a line", "1. a1 a2 2. b1 b2 3. c1 *", ErrSyntax},
+		{"draw with the board open", "1. b2 1/2-1/2", ErrSyntax},
+		{"tie with the board open", "[Termination \"tie\"]\n\n1. b2", ErrSyntax},
+		{"line that isn't there", "[Termination \"line\"]\n\n1. b2 1-0", ErrSyntax},
+		{"forfeit for a line", "[Termination \"forfeit\"]\n\n1. a1 a2 2. b1 b2 3. c1", ErrSyntax},
+		{"forfeit without a winner", "[Termination \"forfeit\"]\n\n1. b2 *", ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Parse([]byte(tt.text))
+			if err == nil {
+				t.Fatalf("Parse() = %+v, want an error", g)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("Parse() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMarshalTextRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		game *Game
+	}{
+		{"bad board", &Game{Config: game.Config{Width: 2, Height: 2, WinLength: 3}}},
+		{"move off the board", &Game{Config: game.DefaultConfig(), Moves: moves(4, 9)}},
+		{"unknown termination", &Game{Config: game.DefaultConfig(), Termination: game.Reason(99)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if text, err := tt.game.MarshalText(); err == nil {
+				t.Errorf("MarshalText() = %s, want an error", text)
+			}
+		})
+	}
+}
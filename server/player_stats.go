@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	statsCollection    = "player_stats"
+	statsKey           = "summary"
+	statsWriteAttempts = 3
+)
+
+// A player's results over every game they have finished, kept in storage and updated as each game ends.
+type playerStats struct {
+	// Results by mode, keyed by modeKey.
+	Modes         map[string]*modeStats `json:"modes"`
+	CurrentStreak int                   `json:"current_streak"`
+	BestStreak    int                   `json:"best_streak"`
+	// Every move the player has made, and the milliseconds they took over them in total.
+	Moves         int   `json:"moves"`
+	MoveTimeTotal int64 `json:"move_time_total"`
+	// Games the player moved first in, and how many of those they won.
+	FirstMoveGames int `json:"first_move_games"`
+	FirstMoveWins  int `json:"first_move_wins"`
+}
+
+type modeStats struct {
+	Fast   bool `json:"fast"`
+	Ai     bool `json:"ai"`
+	Wins   int  `json:"wins"`
+	Losses int  `json:"losses"`
+	Ties   int  `json:"ties"`
+}
+
+func modeKey(fast, ai bool) string {
+	key := "normal"
+	if fast {
+		key = "fast"
+	}
+	if ai {
+		return key + "_ai"
+	}
+	return key + "_human"
+}
+
+// Count a finished game in the stats of each human player in it. The update is retried if another game has changed
+// their stats in the meantime, and failing to is logged, but doesn't hold up the match.
+func updatePlayerStats(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, record *gameRecord) {
+	var err error
+	for attempt := 0; attempt < statsWriteAttempts; attempt++ {
+		if err = writePlayerStats(ctx, nk, record); err == nil {
+			return
+		}
+		logger.Warn("retrying stats update for match %s round %d: %v", record.MatchID, record.Round, err)
+	}
+	logger.Error("Failed updating stats for match %s round %d: %v", record.MatchID, record.Round, err)
+}
+
+// Read the players' stats, add the game to them, and write them back in a single storage write. The write is
+// conditional on the versions read, so it fails rather than lose a concurrent update to either player.
+func writePlayerStats(ctx context.Context, nk runtime.NakamaModule, record *gameRecord) error {
+	ai := false
+	reads := make([]*runtime.StorageRead, 0, len(record.Players))
+	for _, player := range record.Players {
+		if player.UserID == aiUserId {
+			ai = true
+			continue
+		}
+		reads = append(reads, &runtime.StorageRead{
+			Collection: statsCollection,
+			Key:        statsKey,
+			UserID:     player.UserID,
+		})
+	}
+	if len(reads) == 0 {
+		return nil
+	}
+
+	objects, err := nk.StorageRead(ctx, reads)
+	if err != nil {
+		return err
+	}
+
+	writes := make([]*runtime.StorageWrite, 0, len(reads))
+	for _, player := range record.Players {
+		if player.UserID == aiUserId {
+			continue
+		}
+
+		stats := &playerStats{}
+		// A version of "*" only writes the object if it doesn't exist yet.
+		version := "*"
+		for _, object := range objects {
+			if object.UserId == player.UserID {
+				if err := json.Unmarshal([]byte(object.Value), stats); err != nil {
+					return err
+				}
+				version = object.Version
+			}
+		}
+
+		stats.add(record, player.Mark, ai)
+
+		value, err := json.Marshal(stats)
+		if err != nil {
+			return err
+		}
+		writes = append(writes, &runtime.StorageWrite{
+			Collection:      statsCollection,
+			Key:             statsKey,
+			UserID:          player.UserID,
+			Value:           string(value),
+			Version:         version,
+			PermissionRead:  2, // readable by anyone
+			PermissionWrite: 0, // only the server can write
+		})
+	}
+
+	_, err = nk.StorageWrite(ctx, writes)
+	return err
+}
+
+// Count a game played with the given mark. A tie or a loss ends the winning streak.
+func (ps *playerStats) add(record *gameRecord, mark api.Mark, ai bool) {
+	if ps.Modes == nil {
+		ps.Modes = make(map[string]*modeStats, 1)
+	}
+	key := modeKey(record.Fast, ai)
+	mode, ok := ps.Modes[key]
+	if !ok {
+		mode = &modeStats{Fast: record.Fast, Ai: ai}
+		ps.Modes[key] = mode
+	}
+
+	won := record.Winner == mark
+	switch {
+	case won:
+		mode.Wins++
+		ps.CurrentStreak++
+		ps.BestStreak = max(ps.BestStreak, ps.CurrentStreak)
+	case record.Winner == api.Mark_MARK_UNSPECIFIED:
+		mode.Ties++
+		ps.CurrentStreak = 0
+	default:
+		mode.Losses++
+		ps.CurrentStreak = 0
+	}
+
+	// Each move took from the move before it, or the start of the round for the first.
+	last := record.StartedAt
+	for _, move := range record.Moves {
+		if move.Mark == mark {
+			ps.Moves++
+			ps.MoveTimeTotal += move.Time - last
+		}
+		last = move.Time
+	}
+
+	if len(record.Moves) > 0 && record.Moves[0].Mark == mark {
+		ps.FirstMoveGames++
+		if won {
+			ps.FirstMoveWins++
+		}
+	}
+}
+
+// Get the stats of any player, or the caller if no user ID is given.
+func rpcGetPlayerStats(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		callerID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcGetPlayerStatsRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		userID := request.UserId
+		if userID == "" {
+			userID = callerID
+		}
+		if userID == aiUserId {
+			return "", errUserNotFound
+		}
+
+		users, err := nk.UsersGetId(ctx, []string{userID}, nil)
+		if err != nil {
+			return "", errBadInput
+		}
+		if len(users) == 0 {
+			return "", errUserNotFound
+		}
+
+		stats, err := readPlayerStats(ctx, nk, userID)
+		if err != nil {
+			logger.Error("error reading stats for %s: %v", userID, err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(stats.response(userID))
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Read a player's stats, empty if they have never finished a game.
+func readPlayerStats(ctx context.Context, nk runtime.NakamaModule, userID string) (*playerStats, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: statsCollection,
+		Key:        statsKey,
+		UserID:     userID,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	stats := &playerStats{}
+	if len(objects) > 0 {
+		if err := json.Unmarshal([]byte(objects[0].Value), stats); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+func (ps *playerStats) response(userID string) *api.RpcGetPlayerStatsResponse {
+	response := &api.RpcGetPlayerStatsResponse{
+		UserId:         userID,
+		Modes:          make([]*api.ModeStats, 0, len(ps.Modes)),
+		CurrentStreak:  int32(ps.CurrentStreak),
+		BestStreak:     int32(ps.BestStreak),
+		FirstMoveGames: int32(ps.FirstMoveGames),
+		FirstMoveWins:  int32(ps.FirstMoveWins),
+	}
+	// Modes in a fixed order, normal before fast and human before AI.
+	for _, fast := range []bool{false, true} {
+		for _, ai := range []bool{false, true} {
+			mode, ok := ps.Modes[modeKey(fast, ai)]
+			if !ok {
+				continue
+			}
+			response.Wins += int32(mode.Wins)
+			response.Losses += int32(mode.Losses)
+			response.Ties += int32(mode.Ties)
+			response.Modes = append(response.Modes, &api.ModeStats{
+				Fast:   mode.Fast,
+				Ai:     mode.Ai,
+				Wins:   int32(mode.Wins),
+				Losses: int32(mode.Losses),
+				Ties:   int32(mode.Ties),
+			})
+		}
+	}
+	if ps.Moves > 0 {
+		response.AverageMoveTime = ps.MoveTimeTotal / int64(ps.Moves)
+	}
+	if ps.FirstMoveGames > 0 {
+		response.FirstMoveWinRate = float64(ps.FirstMoveWins) / float64(ps.FirstMoveGames)
+	}
+	return response
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// The match each room code refers to, by code, owned by the system user.
+	privateMatchCollection = "private_matches"
+
+	roomCodeLength = 6
+	// No 0 and O, or 1 and I, which are easily mixed up when a code is read out.
+	roomCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	// Codes are picked at random, and picked again if already taken.
+	roomCodeAttempts = 5
+)
+
+type privateMatch struct {
+	MatchID string `json:"match_id"`
+}
+
+// Create a match that isn't listed anywhere, and a room code for the players to share. Only those who join with the
+// code in their join metadata get in.
+func rpcCreatePrivateMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcCreatePrivateMatchRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		config := boardConfig(request.Width, request.Height, request.WinLength)
+		if err := config.Validate(); err != nil {
+			logger.Debug("invalid board requested: %v", err)
+			return "", errBadInput
+		}
+
+		seriesLength := 1
+		if request.SeriesLength > 0 {
+			seriesLength = int(request.SeriesLength)
+		}
+		if !validSeriesLength(seriesLength) {
+			return "", errBadInput
+		}
+
+		// Claim a code before creating the match, so no two matches ever share one.
+		var code, version string
+		for attempt := 0; attempt < roomCodeAttempts && version == ""; attempt++ {
+			var err error
+			if code, err = newRoomCode(); err != nil {
+				logger.Error("error generating room code: %v", err)
+				return "", errInternalError
+			}
+			version, err = writePrivateMatch(ctx, nk, code, "", "*")
+			if err != nil {
+				logger.Warn("retrying room code %s: %v", code, err)
+			}
+		}
+		if version == "" {
+			logger.Error("error claiming a room code after %d attempts", roomCodeAttempts)
+			return "", errInternalError
+		}
+
+		matchID, err := nk.MatchCreate(ctx, moduleName, map[string]interface{}{
+			"fast": request.Fast, "width": config.Width, "height": config.Height, "win_length": config.WinLength,
+			"series_length": seriesLength, "code": code})
+		if err != nil {
+			logger.Error("error creating match: %v", err)
+			// Give the code back, there's no match for it to point at.
+			if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+				Collection: privateMatchCollection,
+				Key:        code,
+				Version:    version,
+			}}); err != nil {
+				logger.Warn("error releasing room code %s: %v", code, err)
+			}
+			return "", errInternalError
+		}
+		if _, err := writePrivateMatch(ctx, nk, code, matchID, version); err != nil {
+			logger.Error("error writing room code %s: %v", code, err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(&api.RpcCreatePrivateMatchResponse{MatchId: matchID, Code: code})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		logger.Info("new private match created %s with code %s", matchID, code)
+		return string(response), nil
+	}
+}
+
+// Find the private match a room code refers to. The code still has to be sent in the join metadata.
+func rpcJoinPrivateMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcJoinPrivateMatchRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		code := normaliseRoomCode(request.Code)
+		if len(code) != roomCodeLength {
+			return "", errPrivateNotFound
+		}
+
+		objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+			Collection: privateMatchCollection,
+			Key:        code,
+		}})
+		if err != nil {
+			logger.Error("error reading room code %s: %v", code, err)
+			return "", errInternalError
+		}
+		if len(objects) == 0 {
+			return "", errPrivateNotFound
+		}
+		match := &privateMatch{}
+		if err := json.Unmarshal([]byte(objects[0].Value), match); err != nil {
+			logger.Error("error decoding room code %s: %v", code, err)
+			return "", errInternalError
+		}
+		if match.MatchID == "" {
+			// Still being set up.
+			return "", errPrivateNotFound
+		}
+
+		// Codes outlive their matches, clear them up once the match is over.
+		if m, err := nk.MatchGet(ctx, match.MatchID); err != nil || m == nil {
+			if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+				Collection: privateMatchCollection,
+				Key:        code,
+				Version:    objects[0].Version,
+			}}); err != nil {
+				logger.Warn("error deleting room code %s: %v", code, err)
+			}
+			return "", errPrivateNotFound
+		}
+
+		response, err := marshaler.Marshal(&api.RpcJoinPrivateMatchResponse{MatchId: match.MatchID})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Point a room code at a match, if the code's version still matches. Returns the new version.
+func writePrivateMatch(ctx context.Context, nk runtime.NakamaModule, code, matchID, version string) (string, error) {
+	value, err := json.Marshal(&privateMatch{MatchID: matchID})
+	if err != nil {
+		return "", err
+	}
+	acks, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      privateMatchCollection,
+		Key:             code,
+		Value:           string(value),
+		Version:         version,
+		PermissionRead:  0,
+		PermissionWrite: 0,
+	}})
+	if err != nil {
+		return "", err
+	}
+	return acks[0].Version, nil
+}
+
+func newRoomCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(roomCodeAlphabet)))
+	code := make([]byte, roomCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = roomCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// Codes are typed in by hand, so case, spaces and dashes don't matter.
+func normaliseRoomCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var errGameRecordNotFound = errors.New("game record not found")
+
+// Get a finished game as the sequence of updates its players saw, to replay on the client.
+func rpcGetReplay(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcGetReplayRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		record, err := readGameRecord(ctx, nk, request.ReplayId)
+		if err != nil {
+			if errors.Is(err, errGameRecordNotFound) {
+				return "", errReplayNotFound
+			}
+			logger.Error("error reading game record: %v", err)
+			return "", errInternalError
+		}
+
+		frames, done, err := replayFrames(record)
+		if err != nil {
+			logger.Error("error replaying game record %s: %v", request.ReplayId, err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(&api.RpcGetReplayResponse{
+			Game:   record.historyGame(),
+			Frames: frames,
+			Done:   done,
+		})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Create a match replaying a finished game to everyone who joins it.
+func rpcWatchReplay(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcWatchReplayRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		speed := 1
+		if request.Speed != 0 {
+			speed = int(request.Speed)
+		}
+		if !validReplaySpeed(speed) {
+			return "", errBadInput
+		}
+
+		// Check the game exists before setting up a match for it.
+		if _, err := readGameRecord(ctx, nk, request.ReplayId); err != nil {
+			if errors.Is(err, errGameRecordNotFound) {
+				return "", errReplayNotFound
+			}
+			logger.Error("error reading game record: %v", err)
+			return "", errInternalError
+		}
+
+		matchID, err := nk.MatchCreate(ctx, replayModuleName, map[string]interface{}{
+			"replay_id": request.ReplayId, "speed": speed})
+		if err != nil {
+			logger.Error("error creating match: %v", err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(&api.RpcWatchReplayResponse{MatchId: matchID})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+func readGameRecord(ctx context.Context, nk runtime.NakamaModule, replayID string) (*gameRecord, error) {
+	if replayID == "" {
+		return nil, errGameRecordNotFound
+	}
+
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: gameRecordCollection,
+		Key:        replayID,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, errGameRecordNotFound
+	}
+
+	record := &gameRecord{}
+	if err := json.Unmarshal([]byte(objects[0].Value), record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Play the recorded moves back on a new board: the empty board first, then the board after every move, and how the
+// game ended.
+func replayFrames(record *gameRecord) ([]*api.ReplayFrame, *api.Done, error) {
+	config := game.Config{Width: record.Width, Height: record.Height, WinLength: record.WinLength}
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	g := game.New(config)
+	frames := make([]*api.ReplayFrame, 0, len(record.Moves)+1)
+	frames = append(frames, &api.ReplayFrame{
+		Position: -1,
+		Update:   &api.Update{Board: g.Board(), Mark: g.Mark()},
+	})
+	for _, move := range record.Moves {
+		if err := g.ApplyMove(move.Mark, move.Position); err != nil {
+			return nil, nil, err
+		}
+		frames = append(frames, &api.ReplayFrame{
+			Offset:   move.Time - record.StartedAt,
+			Position: move.Position,
+			Update:   &api.Update{Board: g.Board(), Mark: g.Mark()},
+		})
+	}
+
+	// Games ending on a forfeit or disconnect have no winning line.
+	done := &api.Done{
+		Board:       g.Board(),
+		Winner:      record.Winner,
+		RoundNumber: record.Round,
+	}
+	if outcome := g.Outcome(); outcome.Done {
+		done.WinnerPositions = outcome.WinnerPositions
+	}
+	return frames, done, nil
+}
+
+// Replays are played at 1, 2, 4 or 8 times the speed of the game.
+func validReplaySpeed(speed int) bool {
+	switch speed {
+	case 1, 2, 4, 8:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	replayModuleName = "replay"
+
+	// How long the final board stays up before the replay closes.
+	replayEndDelaySec = 10
+)
+
+// Compile-time check to make sure all required functions are implemented.
+var _ runtime.Match = &ReplayMatchHandler{}
+
+// ReplayLabel advertises the game a replay match is playing back.
+type ReplayLabel struct {
+	ReplayID string `json:"replay_id"`
+	Speed    int    `json:"speed"`
+}
+
+// ReplayMatchHandler re-broadcasts a finished game to everyone who joins, with the moves spaced out as they were
+// played, or faster. Everyone joins as a spectator, whatever they send is rejected.
+type ReplayMatchHandler struct {
+	marshaler *protojson.MarshalOptions
+}
+
+type ReplayMatchState struct {
+	record *gameRecord
+	frames []*api.ReplayFrame
+	done   *api.Done
+	speed  int
+
+	presences       map[string]runtime.Presence
+	joinsInProgress int
+	emptyTicks      int
+
+	// True once someone has joined and the replay has begun.
+	started bool
+	// Replay time elapsed, in milliseconds of the original game.
+	elapsed int64
+	// The next frame to broadcast. Frames before it have been sent already.
+	nextFrame int
+	// True once the game's result has been sent.
+	ended bool
+	// Ticks until the replay closes, once it has ended.
+	endRemainingTicks int64
+}
+
+func (m *ReplayMatchHandler) MatchInit(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, params map[string]interface{}) (interface{}, int, string) {
+	replayID, _ := params["replay_id"].(string)
+	speed := intParam(params, "speed", 1)
+	if !validReplaySpeed(speed) {
+		logger.Error("invalid match init parameter \"speed\"")
+		return nil, 0, ""
+	}
+
+	record, err := readGameRecord(ctx, nk, replayID)
+	if err != nil {
+		logger.Error("error reading game record %q: %v", replayID, err)
+		return nil, 0, ""
+	}
+	frames, done, err := replayFrames(record)
+	if err != nil {
+		logger.Error("error replaying game record %q: %v", replayID, err)
+		return nil, 0, ""
+	}
+
+	labelJSON, err := json.Marshal(&ReplayLabel{ReplayID: replayID, Speed: speed})
+	if err != nil {
+		logger.WithField("error", err).Error("match init failed")
+		labelJSON = []byte("{}")
+	}
+
+	return &ReplayMatchState{
+		record:    record,
+		frames:    frames,
+		done:      done,
+		speed:     speed,
+		presences: make(map[string]runtime.Presence),
+	}, tickRate, string(labelJSON)
+}
+
+func (m *ReplayMatchHandler) MatchJoinAttempt(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presence runtime.Presence, metadata map[string]string) (interface{}, bool, string) {
+	s := state.(*ReplayMatchState)
+
+	if _, ok := s.presences[presence.GetUserId()]; ok {
+		return s, false, "already joined"
+	}
+	if len(s.presences)+s.joinsInProgress >= maxSpectators {
+		return s, false, "too many spectators"
+	}
+
+	s.joinsInProgress++
+	return s, true, ""
+}
+
+func (m *ReplayMatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
+	s := state.(*ReplayMatchState)
+
+	for _, presence := range presences {
+		s.emptyTicks = 0
+		s.presences[presence.GetUserId()] = presence
+		s.joinsInProgress--
+
+		// Catch up anyone joining a replay already under way.
+		if s.started {
+			m.broadcast(logger, dispatcher, api.OpCode_OPCODE_START, m.startMessage(s), []runtime.Presence{presence})
+			if s.ended {
+				m.broadcast(logger, dispatcher, api.OpCode_OPCODE_DONE, s.done, []runtime.Presence{presence})
+			}
+		}
+	}
+
+	return s
+}
+
+func (m *ReplayMatchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
+	s := state.(*ReplayMatchState)
+
+	for _, presence := range presences {
+		delete(s.presences, presence.GetUserId())
+	}
+
+	return s
+}
+
+func (m *ReplayMatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
+	s := state.(*ReplayMatchState)
+
+	if len(s.presences)+s.joinsInProgress == 0 {
+		s.emptyTicks++
+		if s.emptyTicks >= maxEmptySec*tickRate {
+			// Nobody is watching.
+			logger.Info("closing idle replay match")
+			return nil
+		}
+		return s
+	}
+
+	// Replays are read-only, whatever spectators send is rejected.
+	for _, message := range messages {
+		if presence, ok := s.presences[message.GetUserId()]; ok {
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{presence}, nil, true)
+		}
+	}
+
+	if !s.started {
+		s.started = true
+		s.nextFrame = 1
+		m.broadcast(logger, dispatcher, api.OpCode_OPCODE_START, m.startMessage(s), nil)
+		return s
+	}
+
+	if s.ended {
+		s.endRemainingTicks--
+		if s.endRemainingTicks <= 0 {
+			return nil
+		}
+		return s
+	}
+
+	s.elapsed += int64(s.speed) * 1000 / tickRate
+	for s.nextFrame < len(s.frames) && s.frames[s.nextFrame].Offset <= s.elapsed {
+		m.broadcast(logger, dispatcher, api.OpCode_OPCODE_UPDATE, s.frames[s.nextFrame].Update, nil)
+		s.nextFrame++
+	}
+
+	if s.nextFrame == len(s.frames) {
+		m.broadcast(logger, dispatcher, api.OpCode_OPCODE_DONE, s.done, nil)
+		s.ended = true
+		s.endRemainingTicks = replayEndDelaySec * tickRate
+	}
+
+	return s
+}
+
+func (m *ReplayMatchHandler) MatchSignal(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, data string) (interface{}, string) {
+	return state, ""
+}
+
+func (m *ReplayMatchHandler) MatchTerminate(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, graceSeconds int) interface{} {
+	return state
+}
+
+// The game as it stands in the replay, with the players' marks and board setup.
+func (m *ReplayMatchHandler) startMessage(s *ReplayMatchState) *api.Start {
+	update := s.frames[max(s.nextFrame-1, 0)].Update
+	marks := make(map[string]api.Mark, len(s.record.Players))
+	for _, player := range s.record.Players {
+		marks[player.UserID] = player.Mark
+	}
+
+	return &api.Start{
+		Board:       update.Board,
+		Marks:       marks,
+		Mark:        update.Mark,
+		Width:       int32(s.record.Width),
+		Height:      int32(s.record.Height),
+		WinLength:   int32(s.record.WinLength),
+		RoundNumber: s.record.Round,
+	}
+}
+
+func (m *ReplayMatchHandler) broadcast(logger runtime.Logger, dispatcher runtime.MatchDispatcher, opCode api.OpCode, msg proto.Message, presences []runtime.Presence) {
+	buf, err := m.marshaler.Marshal(msg)
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+		return
+	}
+	_ = dispatcher.BroadcastMessage(int64(opCode), buf, presences, nil, true)
+}
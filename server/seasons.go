@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	nkapi "github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// The final standings of every past season, owned by the system user.
+	seasonResultCollection = "season_results"
+	// Badges players have earned, readable by anyone.
+	badgeCollection = "badges"
+
+	// Standings archived and rewarded when a season ends.
+	seasonArchiveSize = 100
+	// Wallet currency granted as season rewards.
+	rewardCurrency = "coins"
+
+	notificationCodeSeasonReward = 102
+)
+
+// What players finishing a season at up to a rank receive.
+type seasonReward struct {
+	maxRank int64
+	coins   int64
+	// Empty if the bracket doesn't earn a badge.
+	badge string
+}
+
+// Rewards for each seasonal leaderboard, by rank bracket from the top.
+var seasonRewards = map[string][]seasonReward{
+	weeklyLeaderboardId: {
+		{maxRank: 1, coins: 500, badge: "weekly_champion"},
+		{maxRank: 3, coins: 250, badge: "weekly_podium"},
+		{maxRank: 10, coins: 100},
+		{maxRank: 100, coins: 25},
+	},
+	seasonLeaderboardId: {
+		{maxRank: 1, coins: 5000, badge: "season_champion"},
+		{maxRank: 3, coins: 2500, badge: "season_podium"},
+		{maxRank: 10, coins: 1000, badge: "season_top_10"},
+		{maxRank: 100, coins: 250},
+	},
+}
+
+// The final standings of a season, and what each player was awarded.
+type seasonResult struct {
+	LeaderboardID string `json:"leaderboard_id"`
+	// When the season ended, in Unix seconds.
+	EndedAt int64          `json:"ended_at"`
+	Records []seasonRecord `json:"records"`
+}
+
+type seasonRecord struct {
+	Rank     int64  `json:"rank"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Score    int64  `json:"score"`
+	Coins    int64  `json:"coins"`
+	Badge    string `json:"badge,omitempty"`
+}
+
+// A badge earned by finishing a season in a top bracket.
+type badge struct {
+	Badge         string `json:"badge"`
+	LeaderboardID string `json:"leaderboard_id"`
+	EndedAt       int64  `json:"ended_at"`
+	Rank          int64  `json:"rank"`
+}
+
+// Archive the final standings of a season as its leaderboard resets, and reward the players at the top. The archive
+// is only written if it doesn't exist yet, so each season is rewarded once.
+func leaderboardReset(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, leaderboard *nkapi.Leaderboard, reset int64) error {
+	rewards, ok := seasonRewards[leaderboard.Id]
+	if !ok {
+		return nil
+	}
+
+	// Records of the season that has just ended are listed by the time it ended.
+	records, _, _, _, err := nk.LeaderboardRecordsList(ctx, leaderboard.Id, nil, seasonArchiveSize, "", reset)
+	if err != nil {
+		logger.Error("error listing leaderboard %s records: %v", leaderboard.Id, err)
+		return err
+	}
+
+	result := &seasonResult{
+		LeaderboardID: leaderboard.Id,
+		EndedAt:       reset,
+		Records:       make([]seasonRecord, 0, len(records)),
+	}
+	for _, record := range records {
+		reward := seasonRewardFor(rewards, record.Rank)
+		result.Records = append(result.Records, seasonRecord{
+			Rank:     record.Rank,
+			UserID:   record.OwnerId,
+			Username: record.Username.GetValue(),
+			Score:    record.Score,
+			Coins:    reward.coins,
+			Badge:    reward.badge,
+		})
+	}
+
+	value, err := json.Marshal(result)
+	if err != nil {
+		logger.Error("error encoding season result: %v", err)
+		return err
+	}
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection: seasonResultCollection,
+		Key:        seasonResultKey(result),
+		// A version of "*" only writes the object if it doesn't exist yet.
+		Version:         "*",
+		Value:           string(value),
+		PermissionRead:  0,
+		PermissionWrite: 0,
+	}}); err != nil {
+		logger.Error("error archiving leaderboard %s season ending %d, it may have been archived already: %v",
+			leaderboard.Id, reset, err)
+		return err
+	}
+
+	grantSeasonRewards(ctx, nk, logger, result)
+	logger.Info("Leaderboard '%s' season ending %d archived with %d records.", leaderboard.Id, reset, len(records))
+	return nil
+}
+
+// The reward for finishing a season at a rank, if any.
+func seasonRewardFor(rewards []seasonReward, rank int64) seasonReward {
+	for _, reward := range rewards {
+		if rank <= reward.maxRank {
+			return reward
+		}
+	}
+	return seasonReward{}
+}
+
+// Storage lists objects in key order, so counting down from the latest possible time puts the most recent seasons
+// first.
+func seasonResultKey(result *seasonResult) string {
+	return fmt.Sprintf("%019d.%s", math.MaxInt64-result.EndedAt, result.LeaderboardID)
+}
+
+// Pay out a season's coins and badges, and let the players know. Failing to is logged, the season stays archived.
+func grantSeasonRewards(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, result *seasonResult) {
+	var wallets []*runtime.WalletUpdate
+	var badges []*runtime.StorageWrite
+	var notifications []*runtime.NotificationSend
+	for _, record := range result.Records {
+		if record.Coins == 0 && record.Badge == "" {
+			continue
+		}
+
+		metadata := map[string]interface{}{
+			"reason":         "season_reward",
+			"leaderboard_id": result.LeaderboardID,
+			"ended_at":       result.EndedAt,
+			"rank":           record.Rank,
+		}
+		if record.Coins > 0 {
+			wallets = append(wallets, &runtime.WalletUpdate{
+				UserID:    record.UserID,
+				Changeset: map[string]int64{rewardCurrency: record.Coins},
+				Metadata:  metadata,
+			})
+		}
+		if record.Badge != "" {
+			value, err := json.Marshal(&badge{
+				Badge:         record.Badge,
+				LeaderboardID: result.LeaderboardID,
+				EndedAt:       result.EndedAt,
+				Rank:          record.Rank,
+			})
+			if err != nil {
+				logger.Error("error encoding badge: %v", err)
+				continue
+			}
+			badges = append(badges, &runtime.StorageWrite{
+				Collection:      badgeCollection,
+				Key:             fmt.Sprintf("%s.%d", record.Badge, result.EndedAt),
+				UserID:          record.UserID,
+				Value:           string(value),
+				PermissionRead:  2, // readable by anyone
+				PermissionWrite: 0, // only the server can write
+			})
+		}
+
+		content := map[string]interface{}{
+			"leaderboard_id": result.LeaderboardID,
+			"ended_at":       result.EndedAt,
+			"rank":           record.Rank,
+			"coins":          record.Coins,
+		}
+		if record.Badge != "" {
+			content["badge"] = record.Badge
+		}
+		notifications = append(notifications, &runtime.NotificationSend{
+			UserID:     record.UserID,
+			Subject:    "Season rewards",
+			Content:    content,
+			Code:       notificationCodeSeasonReward,
+			Persistent: true,
+		})
+	}
+
+	if len(wallets) > 0 {
+		if _, err := nk.WalletsUpdate(ctx, wallets, true); err != nil {
+			logger.Error("error granting season coins: %v", err)
+		}
+	}
+	if len(badges) > 0 {
+		if _, err := nk.StorageWrite(ctx, badges); err != nil {
+			logger.Error("error granting season badges: %v", err)
+		}
+	}
+	if len(notifications) > 0 {
+		if err := nk.NotificationsSend(ctx, notifications); err != nil {
+			logger.Error("error sending season reward notifications: %v", err)
+		}
+	}
+}
+
+// List the final standings of past seasons, most recent first, with the caller's own result in each if they placed.
+func rpcListSeasonResults(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcListSeasonResultsRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		if _, ok := seasonRewards[request.LeaderboardId]; request.LeaderboardId != "" && !ok {
+			return "", errBadInput
+		}
+
+		limit := 10
+		if request.Limit > 0 && request.Limit < 100 {
+			limit = int(request.Limit)
+		}
+
+		// Both leaderboards' seasons share the collection, so those of the other one are skipped over. Each page asks for
+		// no more than are still wanted, so the cursor never passes a season that wasn't returned.
+		var results []*seasonResult
+		cursor := request.Cursor
+		for {
+			var objects []*nkapi.StorageObject
+			var err error
+			objects, cursor, err = nk.StorageList(ctx, "", "", seasonResultCollection, limit-len(results), cursor)
+			if err != nil {
+				logger.Error("error listing season results: %v", err)
+				return "", errInternalError
+			}
+			for _, object := range objects {
+				result := &seasonResult{}
+				if err := json.Unmarshal([]byte(object.Value), result); err != nil {
+					logger.Warn("error decoding season result %s: %v", object.Key, err)
+					continue
+				}
+				if request.LeaderboardId == "" || result.LeaderboardID == request.LeaderboardId {
+					results = append(results, result)
+				}
+			}
+			if cursor == "" || len(results) >= limit {
+				break
+			}
+		}
+
+		seasons := make([]*api.SeasonResult, 0, len(results))
+		for _, result := range results {
+			season := &api.SeasonResult{
+				LeaderboardId: result.LeaderboardID,
+				EndedAt:       result.EndedAt,
+				Records:       make([]*api.SeasonRecord, 0, len(result.Records)),
+			}
+			for _, record := range result.Records {
+				seasonRecord := &api.SeasonRecord{
+					Rank:     record.Rank,
+					UserId:   record.UserID,
+					Username: record.Username,
+					Score:    record.Score,
+					Coins:    record.Coins,
+					Badge:    record.Badge,
+				}
+				season.Records = append(season.Records, seasonRecord)
+				if record.UserID == userID {
+					season.OwnRecord = seasonRecord
+				}
+			}
+			seasons = append(seasons, season)
+		}
+
+		response, err := marshaler.Marshal(&api.RpcListSeasonResultsResponse{Seasons: seasons, Cursor: cursor})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
@@ -49,7 +49,8 @@ func eventSessionEndFunc(db *sql.DB) func(context.Context, runtime.Logger, *api.
 		}
 
 		// Restrict the time allowed with the DB operation so we can fail fast in a stampeding herd scenario.
-		ctx2, _ := context.WithTimeout(ctx, 1*time.Second)
+		ctx2, cancel := context.WithTimeout(ctx, 1*time.Second)
+		defer cancel()
 		query := `
 UPDATE
     users AS u
@@ -68,7 +69,8 @@ WHERE
 	}
 }
 
-// Limit the number of concurrent realtime sessions active for a user to just one.
+// Limit the number of concurrent realtime sessions active for a user to just one, and point them back to any match
+// they have a round in progress in.
 func eventSessionStartFunc(nk runtime.NakamaModule) func(context.Context, runtime.Logger, *api.Event) {
 	return func(ctx context.Context, logger runtime.Logger, evt *api.Event) {
 		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
@@ -108,17 +110,46 @@ func eventSessionStartFunc(nk runtime.NakamaModule) func(context.Context, runtim
 				continue
 			}
 
-			ctx2, _ := context.WithTimeout(context.Background(), 3*time.Second)
-			if err := nk.NotificationsSend(ctx2, notifications); err != nil {
-				logger.WithField("err", err).Error("nk.NotificationsSend error.")
-				continue
-			}
-
-			// Force disconnect the socket for the user's other game client.
-			if err := nk.SessionDisconnect(ctx2, presence.GetSessionId()); err != nil {
-				logger.WithField("err", err).Error("nk.SessionDisconnect error.")
-				continue
-			}
+			kickSession(nk, logger, presence.GetSessionId(), notifications)
 		}
+
+		// The client may have been restarted mid-game and lost track of the match, send it the match ID to rejoin.
+		notifyActiveMatch(nk, logger, userID)
+	}
+}
+
+// Tell one of the user's other game clients why it's being disconnected, then disconnect it.
+func kickSession(nk runtime.NakamaModule, logger runtime.Logger, sessionID string, notifications []*runtime.NotificationSend) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := nk.NotificationsSend(ctx, notifications); err != nil {
+		logger.WithField("err", err).Error("nk.NotificationsSend error.")
+		return
+	}
+
+	// Force disconnect the socket for the user's other game client.
+	if err := nk.SessionDisconnect(ctx, sessionID); err != nil {
+		logger.WithField("err", err).Error("nk.SessionDisconnect error.")
+	}
+}
+
+// Send the user the ID of the match they have a round in progress in, if any.
+func notifyActiveMatch(nk runtime.NakamaModule, logger runtime.Logger, userID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	matchID, err := readActiveMatch(ctx, nk, logger, userID)
+	if err != nil {
+		logger.WithField("err", err).Error("readActiveMatch error.")
+		return
+	}
+	if matchID == "" {
+		return
+	}
+	if err := nk.NotificationSend(ctx, userID, "Game in progress", map[string]interface{}{
+		"match_id": matchID,
+	}, notificationCodeActiveMatch, "", false); err != nil {
+		logger.WithField("err", err).Error("nk.NotificationSend error.")
 	}
 }
@@ -0,0 +1,186 @@
+// Package solver plays tic-tac-toe perfectly, using a minimax search with alpha-beta pruning. Positions already
+// searched are remembered, so after the first few games nearly every move is a table lookup.
+package solver
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+// Boards with more empty cells than this can't be searched exhaustively in the time a player has for a move.
+const MaxEmptyCells = 9
+
+var (
+	ErrGameOver      = errors.New("game is over")
+	ErrBoardTooLarge = errors.New("too many empty cells to search")
+)
+
+type bound int
+
+const (
+	exact bound = iota
+	lower
+	upper
+)
+
+type entry struct {
+	score int
+	bound bound
+}
+
+// Solver finds the best move in any position. It is safe for concurrent use, and the positions it remembers are
+// shared by everyone using it. Searches run side by side, only looking up and remembering positions is serialised.
+type Solver struct {
+	mu    sync.RWMutex
+	table map[string]entry
+}
+
+func New() *Solver {
+	return &Solver{
+		table: make(map[string]entry),
+	}
+}
+
+// BestMove returns the position the player whose turn it is should play. Among equally good moves it picks the
+// fastest win or the slowest loss.
+func (s *Solver) BestMove(ctx context.Context, g *game.Game) (int32, error) {
+	scores, err := s.Evaluate(ctx, g)
+	if err != nil {
+		return -1, err
+	}
+
+	bestMove := int32(-1)
+	bestScore := math.MinInt
+	for _, move := range g.LegalMoves() {
+		if scores[move] > bestScore {
+			bestMove = move
+			bestScore = scores[move]
+		}
+	}
+
+	return bestMove, nil
+}
+
+// Evaluate scores every legal move for the player whose turn it is, assuming best play from then on: positive if it
+// wins, the more so the sooner, negative if it loses, and zero if it draws. The search gives up with the context's
+// error once it's done.
+func (s *Solver) Evaluate(ctx context.Context, g *game.Game) (map[int32]int, error) {
+	moves := g.LegalMoves()
+	if len(moves) == 0 {
+		return nil, ErrGameOver
+	}
+	if len(moves) > MaxEmptyCells {
+		return nil, ErrBoardTooLarge
+	}
+
+	scores := make(map[int32]int, len(moves))
+	for _, move := range moves {
+		next := g.Clone()
+		if err := next.ApplyMove(next.Mark(), move); err != nil {
+			return nil, err
+		}
+		score, err := s.negamax(ctx, next, math.MinInt+1, math.MaxInt)
+		if err != nil {
+			return nil, err
+		}
+		scores[move] = -score
+	}
+
+	return scores, nil
+}
+
+// Score the position from the point of view of the player whose turn it is: positive if they win with best play,
+// the more so the sooner, negative if they lose, and zero for a draw. Positions are only remembered once fully
+// searched, so giving up part way leaves the table as it was.
+func (s *Solver) negamax(ctx context.Context, g *game.Game, alpha, beta int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	board := g.Board()
+	empty := 0
+	for _, mark := range board {
+		if mark == api.Mark_MARK_UNSPECIFIED {
+			empty++
+		}
+	}
+
+	if outcome := g.Outcome(); outcome.Done {
+		if outcome.Winner == api.Mark_MARK_UNSPECIFIED {
+			return 0, nil
+		}
+		// The opponent has just completed a line.
+		return -(1 + empty), nil
+	}
+
+	key := positionKey(g.Config(), board, g.Mark())
+	alphaOrig := alpha
+	if e, ok := s.lookup(key); ok {
+		switch e.bound {
+		case exact:
+			return e.score, nil
+		case lower:
+			alpha = max(alpha, e.score)
+		case upper:
+			beta = min(beta, e.score)
+		}
+		if alpha >= beta {
+			return e.score, nil
+		}
+	}
+
+	best := math.MinInt + 1
+	for _, move := range g.LegalMoves() {
+		next := g.Clone()
+		_ = next.ApplyMove(next.Mark(), move)
+
+		score, err := s.negamax(ctx, next, -beta, -alpha)
+		if err != nil {
+			return 0, err
+		}
+		score = -score
+		best = max(best, score)
+		alpha = max(alpha, score)
+		if alpha >= beta {
+			break
+		}
+	}
+
+	e := entry{score: best, bound: exact}
+	if best <= alphaOrig {
+		e.bound = upper
+	} else if best >= beta {
+		e.bound = lower
+	}
+	s.store(key, e)
+
+	return best, nil
+}
+
+func (s *Solver) lookup(key string) (entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.table[key]
+	return e, ok
+}
+
+func (s *Solver) store(key string, e entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.table[key] = e
+}
+
+// Positions are keyed by board dimensions, the cells, and whose turn it is.
+func positionKey(config game.Config, board []api.Mark, mark api.Mark) string {
+	key := make([]byte, 0, len(board)+4)
+	key = append(key, byte(config.Width), byte(config.Height), byte(config.WinLength), byte(mark))
+	for _, m := range board {
+		key = append(key, byte(m))
+	}
+	return string(key)
+}
@@ -0,0 +1,199 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+	"github.com/heroiclabs/nakama-project-template/game"
+)
+
+// Play the positions in turn, X first, failing the test on any rejected move.
+func play(t *testing.T, config game.Config, positions ...int32) *game.Game {
+	t.Helper()
+	g := game.New(config)
+	for _, pos := range positions {
+		if err := g.ApplyMove(g.Mark(), pos); err != nil {
+			t.Fatalf("move %d: %v", pos, err)
+		}
+	}
+	return g
+}
+
+func TestEvaluateEmptyBoard(t *testing.T) {
+	scores, err := New().Evaluate(context.Background(), game.New(game.DefaultConfig()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every opening move draws with best play.
+	for pos, score := range scores {
+		if score != 0 {
+			t.Errorf("opening %d scored %d, want a draw", pos, score)
+		}
+	}
+	if len(scores) != 9 {
+		t.Errorf("got %d scores, want 9", len(scores))
+	}
+}
+
+func TestBestMove(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []int32
+		want  int32
+	}{
+		// X X .
+		// O O .
+		// . . .
+		{"win rather than block", []int32{0, 3, 1, 4}, 2},
+		// X X .
+		// . O .
+		// . . .
+		{"forced block", []int32{0, 4, 1}, 2},
+		// X O .
+		// . X .
+		// . . .
+		{"block a diagonal", []int32{0, 1, 4}, 8},
+		// X O X
+		// . O .
+		// X . .
+		{"win rather than block for O", []int32{0, 1, 2, 4, 6}, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := play(t, game.DefaultConfig(), tt.moves...)
+			if move, err := New().BestMove(context.Background(), g); err != nil || move != tt.want {
+				t.Errorf("BestMove() = %d, %v, want %d", move, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateWins(t *testing.T) {
+	// X X .
+	// O O .
+	// . . .
+	// X wins at once with 2, at the last moment with 5 or 8 is too late as O wins first.
+	scores, err := New().Evaluate(context.Background(), play(t, game.DefaultConfig(), 0, 3, 1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scores[2] <= 0 {
+		t.Errorf("winning move scored %d, want a win", scores[2])
+	}
+	for pos, score := range scores {
+		if pos != 2 && pos != 5 && score >= 0 {
+			t.Errorf("move %d scored %d, want a loss", pos, score)
+		}
+		if pos != 2 && score >= scores[2] {
+			t.Errorf("move %d scored %d, want less than the win at once", pos, score)
+		}
+	}
+}
+
+func TestEvaluateRejected(t *testing.T) {
+	if _, err := New().Evaluate(context.Background(), play(t, game.DefaultConfig(), 0, 3, 1, 4, 2)); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Evaluate() = %v after the game is over, want %v", err, ErrGameOver)
+	}
+	g := game.New(game.Config{Width: 4, Height: 4, WinLength: 3})
+	if _, err := New().Evaluate(context.Background(), g); !errors.Is(err, ErrBoardTooLarge) {
+		t.Errorf("Evaluate() = %v on a 4x4 board, want %v", err, ErrBoardTooLarge)
+	}
+}
+
+func TestEvaluateCancelled(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Evaluate(ctx, game.New(game.DefaultConfig())); !errors.Is(err, context.Canceled) {
+		t.Errorf("Evaluate() = %v, want %v", err, context.Canceled)
+	}
+	if len(s.table) != 0 {
+		t.Errorf("remembered %d positions from a search given up on", len(s.table))
+	}
+}
+
+func TestEvaluateWarmTable(t *testing.T) {
+	// Every position the game can reach with a move left to play, each move in each one scored without pruning.
+	var positions []*game.Game
+	seen := make(map[string]bool)
+	var walk func(g *game.Game)
+	walk = func(g *game.Game) {
+		key := positionKey(g.Config(), g.Board(), g.Mark())
+		if seen[key] || g.Outcome().Done {
+			return
+		}
+		seen[key] = true
+		positions = append(positions, g)
+		for _, move := range g.LegalMoves() {
+			next := g.Clone()
+			_ = next.ApplyMove(next.Mark(), move)
+			walk(next)
+		}
+	}
+	walk(game.New(game.DefaultConfig()))
+
+	memo := make(map[string]int)
+	// The table fills with bounds as well as exact scores as the same solver goes from one position to the next, which
+	// mustn't change a single score from what a solver starting afresh finds.
+	warm := New()
+	for _, g := range positions {
+		want := make(map[int32]int)
+		for _, move := range g.LegalMoves() {
+			next := g.Clone()
+			_ = next.ApplyMove(next.Mark(), move)
+			want[move] = -minimax(next, memo)
+		}
+
+		cold, err := New().Evaluate(context.Background(), g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(cold, want) {
+			t.Fatalf("Evaluate(%v) = %v on an empty table, want %v", g.Board(), cold, want)
+		}
+		scores, err := warm.Evaluate(context.Background(), g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(scores, want) {
+			t.Fatalf("Evaluate(%v) = %v on a warm table, want %v", g.Board(), scores, want)
+		}
+	}
+	if len(positions) != 4520 {
+		t.Errorf("walked %d positions, want 4520", len(positions))
+	}
+}
+
+// Score a position without pruning or a table, to check the solver against.
+func minimax(g *game.Game, memo map[string]int) int {
+	board := g.Board()
+	key := positionKey(g.Config(), board, g.Mark())
+	if score, ok := memo[key]; ok {
+		return score
+	}
+
+	score := 0
+	if outcome := g.Outcome(); outcome.Done {
+		if outcome.Winner != api.Mark_MARK_UNSPECIFIED {
+			empty := 0
+			for _, mark := range board {
+				if mark == api.Mark_MARK_UNSPECIFIED {
+					empty++
+				}
+			}
+			score = -(1 + empty)
+		}
+	} else {
+		score = -1 << 30
+		for _, move := range g.LegalMoves() {
+			next := g.Clone()
+			_ = next.ApplyMove(next.Mark(), move)
+			score = max(score, -minimax(next, memo))
+		}
+	}
+	memo[key] = score
+	return score
+}
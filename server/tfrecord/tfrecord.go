@@ -0,0 +1,108 @@
+// Package tfrecord writes tf.train.Example records in TensorFlow's TFRecord format, so data exported by the server
+// can be read with tf.data.TFRecordDataset without pulling TensorFlow into the server.
+package tfrecord
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Feature is one named value of an Example. Only one of the lists should be set.
+type Feature struct {
+	Floats []float32
+	Ints   []int64
+	Bytes  [][]byte
+}
+
+// Example is a tf.train.Example, by feature name.
+type Example map[string]Feature
+
+// Marshal encodes the example as a tf.train.Example protobuf message. Features are written in name order, so the
+// same example always encodes the same way.
+func (e Example) Marshal() []byte {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Features: map<string, Feature> feature = 1.
+	var features []byte
+	for _, name := range names {
+		var entry []byte
+		entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+		entry = protowire.AppendString(entry, name)
+		entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+		entry = protowire.AppendBytes(entry, e[name].marshal())
+
+		features = protowire.AppendTag(features, 1, protowire.BytesType)
+		features = protowire.AppendBytes(features, entry)
+	}
+
+	// Example: Features features = 1.
+	var example []byte
+	example = protowire.AppendTag(example, 1, protowire.BytesType)
+	example = protowire.AppendBytes(example, features)
+	return example
+}
+
+// Feature: oneof BytesList bytes_list = 1, FloatList float_list = 2, Int64List int64_list = 3. Each list holds its
+// values in field 1, packed for numbers.
+func (f Feature) marshal() []byte {
+	var list []byte
+	var field protowire.Number
+	switch {
+	case f.Floats != nil:
+		field = 2
+		var packed []byte
+		for _, v := range f.Floats {
+			packed = protowire.AppendFixed32(packed, math.Float32bits(v))
+		}
+		list = protowire.AppendTag(list, 1, protowire.BytesType)
+		list = protowire.AppendBytes(list, packed)
+	case f.Ints != nil:
+		field = 3
+		var packed []byte
+		for _, v := range f.Ints {
+			packed = protowire.AppendVarint(packed, uint64(v))
+		}
+		list = protowire.AppendTag(list, 1, protowire.BytesType)
+		list = protowire.AppendBytes(list, packed)
+	default:
+		field = 1
+		for _, v := range f.Bytes {
+			list = protowire.AppendTag(list, 1, protowire.BytesType)
+			list = protowire.AppendBytes(list, v)
+		}
+	}
+
+	var feature []byte
+	feature = protowire.AppendTag(feature, field, protowire.BytesType)
+	feature = protowire.AppendBytes(feature, list)
+	return feature
+}
+
+// AppendRecord appends a single TFRecord to dst: the length of the data, its checksum, the data itself, and the
+// data's checksum.
+func AppendRecord(dst []byte, data []byte) []byte {
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(data)))
+
+	dst = append(dst, length[:]...)
+	dst = binary.LittleEndian.AppendUint32(dst, maskedCRC(length[:]))
+	dst = append(dst, data...)
+	dst = binary.LittleEndian.AppendUint32(dst, maskedCRC(data))
+	return dst
+}
+
+// TFRecord checksums are CRC-32C, rotated and offset so data containing its own checksum doesn't confuse readers.
+func maskedCRC(data []byte) uint32 {
+	crc := crc32.Checksum(data, castagnoli)
+	return (crc>>15 | crc<<17) + 0xa282ead8
+}